@@ -0,0 +1,10 @@
+// Package api holds notes.proto, the contract of the gRPC and REST APIs, and the
+// OpenAPI document generated from it.
+package api
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI v2 document of the REST API served by the gateway.
+//
+//go:embed notes.swagger.json
+var OpenAPI []byte
@@ -3,24 +3,44 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
 	"notes/internal/notes/app"
+	"notes/internal/notes/controller"
 	"notes/internal/notes/controller/notes"
+	"notes/internal/notes/events"
+	"notes/internal/notes/idempotency"
+	"notes/internal/notes/ratelimit"
+	"notes/internal/notes/server"
+	"notes/internal/notes/server/connectserver"
 	"notes/internal/notes/storage/postgres"
+	"notes/internal/pkg/auth"
 	"notes/internal/pkg/config"
+	"notes/internal/pkg/health"
 	"notes/internal/pkg/logger"
+	"notes/internal/pkg/metrics"
+	"notes/internal/pkg/models"
+	"notes/internal/pkg/tracing"
 	"os/signal"
 	"sync"
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
 
-var configPath string
+var (
+	configPath string
+	issueToken string
+	tokenTTL   time.Duration
+)
 
 func init() {
 	flag.StringVar(&configPath, "config", "./config/local.yaml", "config path")
+	flag.StringVar(&issueToken, "issue-service-token", "",
+		"print a JWT for the named service principal and exit")
+	flag.DurationVar(&tokenTTL, "token-ttl", time.Hour*24*365, "lifetime of an issued token")
 }
 
 func main() {
@@ -30,6 +50,18 @@ func main() {
 		log.Fatalf("can not set up config error: %s", err.Error())
 	}
 
+	if issueToken != "" {
+		token, err := auth.New(cfg.Auth, nil).IssueToken(auth.Principal{
+			Name: issueToken,
+			Kind: auth.KindService,
+		}, tokenTTL)
+		if err != nil {
+			log.Fatalf("can not issue token error: %s", err.Error())
+		}
+		fmt.Println(token)
+		return
+	}
+
 	logg, err := logger.New(cfg.Env)
 	if err != nil {
 		log.Fatalf("can not set up logger error: %s", err.Error())
@@ -39,31 +71,109 @@ func main() {
 		syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM, syscall.SIGHUP)
 	defer cancel()
 
+	shutdownTracing, err := tracing.New(ctx, cfg.Tracing, "notes")
+	if err != nil {
+		logg.Fatal("tracing initializing failed", zap.String("error", err.Error()))
+	}
+
 	ctxS, cancelS := context.WithTimeout(ctx, time.Second*5)
 	defer cancelS()
 	strPostgres, err := postgres.New(ctxS, cfg)
 	if err != nil {
-		logg.Error("storage initializing error", zap.Error(err))
+		logg.Fatal("storage initializing error", zap.Error(err))
 	}
+	defer strPostgres.Close()
+	prometheus.MustRegister(postgres.NewPoolCollector(strPostgres))
+
+	h := health.New(time.Second * 2)
+	h.AddReadinessCheck("postgres", strPostgres.Ping)
+	h.AddReadinessCheck("migrations", strPostgres.CheckMigrations(cfg.DB.Version))
 
-	a := app.NewApp(strPostgres)
+	a := app.NewApp(strPostgres, app.WithQuotas(cfg.Quotas))
 
-	s, err := notes.New(notes.RestAPI, cfg, a, logg)
+	bus := events.New(cfg.Events)
+	go func() {
+		if err := strPostgres.Listen(ctx, func(e models.NoteEvent) { bus.Publish(e) }); err != nil {
+			logg.Error("listening to note events failed", zap.Error(err))
+		}
+	}()
+	if cfg.Events.DuePoll > 0 {
+		go bus.PublishDue(ctx, a, cfg.Events.DuePoll, logg)
+	}
+
+	keys := idempotency.New(strPostgres, cfg.Idempotency)
+	if cfg.Idempotency.Purge > 0 {
+		go keys.PurgeExpired(ctx, cfg.Idempotency.Purge, logg)
+	}
+
+	authenticator := auth.New(cfg.Auth, strPostgres)
+	adminInfo := server.AdminInfo{
+		Config:           cfg,
+		Logger:           logg,
+		MigrationVersion: strPostgres.MigrationVersion,
+		Users:            strPostgres,
+	}
+	if cfg.Auth.JWTSecret != "" {
+		adminInfo.Tokens = authenticator
+	}
+	opts := []server.Option{
+		server.WithHealth(h), server.WithEvents(bus), server.WithIdempotency(keys),
+		server.WithAdminInfo(adminInfo),
+	}
+	if cfg.Auth.Enabled {
+		opts = append(opts, server.WithAuthenticator(authenticator))
+	}
+	if cfg.RateLimit.Enabled {
+		limiter := ratelimit.New(cfg.RateLimit)
+		if cfg.RateLimit.Idle > 0 {
+			go limiter.PurgeIdle(ctx, cfg.RateLimit.Idle)
+		}
+		opts = append(opts, server.WithRateLimiter(limiter))
+	}
+
+	var sC *connectserver.Server
+	restOpts := opts
+	if cfg.Connect.Enabled {
+		sC, err = connectserver.New(ctx, cfg.Connect, cfg.GRPCServer)
+		if err != nil {
+			logg.Fatal("service initializing failed", zap.String("error", err.Error()))
+		}
+		if cfg.Connect.Port == "" {
+			restOpts = append(restOpts[:len(restOpts):len(restOpts)], server.WithConnect(sC.Handler()))
+		}
+	}
+
+	s, err := notes.New(notes.RestAPI, cfg, a, logg, restOpts...)
 	if err != nil {
 		logg.Fatal("service initializing failed", zap.String("error", err.Error()))
 	}
 
-	sG, err := notes.New(notes.GRPCAPI, cfg, a, logg)
+	sG, err := notes.New(notes.GRPCAPI, cfg, a, logg, opts...)
 	if err != nil {
 		logg.Fatal("service initializing failed", zap.String("error", err.Error()))
 	}
 
+	var sGW controller.API
+	if cfg.Gateway.Port != "" {
+		sGW, err = notes.New(notes.GatewayAPI, cfg, a, logg, opts...)
+		if err != nil {
+			logg.Fatal("service initializing failed", zap.String("error", err.Error()))
+		}
+	}
+
+	m := metrics.New(cfg.Metrics)
+	m.Handle("/healthz", h.LiveHandler())
+	m.Handle("/readyz", h.ReadyHandler())
+
 	go func() {
 		<-ctx.Done()
 
 		ctxS, cancel := context.WithTimeout(context.Background(), time.Second*5)
 		defer cancel()
 
+		// Watch streams only end with their subscriptions, graceful stops wait for them.
+		bus.Close()
+
 		if err := s.Shutdown(ctxS); err != nil {
 			logg.Error("can not shutdown REST server", zap.String("error", err.Error()))
 		}
@@ -72,6 +182,26 @@ func main() {
 			logg.Error("can not shutdown GRPC server", zap.String("error", err.Error()))
 		}
 
+		if sGW != nil {
+			if err := sGW.Shutdown(ctxS); err != nil {
+				logg.Error("can not shutdown gateway server", zap.String("error", err.Error()))
+			}
+		}
+
+		if sC != nil {
+			if err := sC.Shutdown(ctxS); err != nil {
+				logg.Error("can not shutdown connect server", zap.String("error", err.Error()))
+			}
+		}
+
+		if err := m.Shutdown(ctxS); err != nil {
+			logg.Error("can not shutdown metrics server", zap.String("error", err.Error()))
+		}
+
+		if err := shutdownTracing(ctxS); err != nil {
+			logg.Error("can not shutdown tracing", zap.String("error", err.Error()))
+		}
+
 		logg.Info("server shutdown success")
 	}()
 	wg := sync.WaitGroup{}
@@ -91,6 +221,39 @@ func main() {
 		}
 		wg.Done()
 	}()
+
+	if sGW != nil {
+		wg.Add(1)
+		go func() {
+			logg.Info("started gateway REST API on", zap.String("address", cfg.Gateway.Host+cfg.Gateway.Port))
+			if err := sGW.Start(ctx); err != nil {
+				logg.Error("can not start gateway server", zap.String("error", err.Error()))
+			}
+			wg.Done()
+		}()
+	}
+
+	if sC != nil && cfg.Connect.Port != "" {
+		wg.Add(1)
+		go func() {
+			logg.Info("started connect API on", zap.String("address", cfg.Connect.Host+cfg.Connect.Port))
+			if err := sC.Start(ctx); err != nil {
+				logg.Error("can not start connect server", zap.String("error", err.Error()))
+			}
+			wg.Done()
+		}()
+	}
+
+	if cfg.Metrics.Port != "" {
+		wg.Add(1)
+		go func() {
+			logg.Info("started metrics on", zap.String("address", cfg.Metrics.Host+cfg.Metrics.Port))
+			if err := m.Start(ctx); err != nil {
+				logg.Error("can not start metrics server", zap.String("error", err.Error()))
+			}
+			wg.Done()
+		}()
+	}
 	wg.Wait()
 
 	// autotls.RunWithContext()
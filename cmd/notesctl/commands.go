@@ -0,0 +1,348 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"notes/internal/pkg/models"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var ErrUsage = errors.New("usage")
+
+// env is what commands run with.
+type env struct {
+	api    api
+	out    printer
+	stdin  io.Reader
+	stderr io.Writer
+}
+
+type command struct {
+	name, args, help string
+	run              func(ctx context.Context, e env, args []string) error
+}
+
+var commands = []command{
+	{"list", "[-interval 5m] [-tags a,b] [-all-tags] [-sort date_notify] [-desc] [-limit n]",
+		"list notes, due within the interval and with the tags", list},
+	{"get", "<id>", "show a note", get},
+	{"create", "-title t [-description d] [-tags a,b]", "create a note", create},
+	{"edit", "<id>", "edit a note in $EDITOR", edit},
+	{"delete", "[-version n] <id>", "delete a note, only at the version when it is set", del},
+	{"refresh", "<id>", "reschedule a due note after its delay", refresh},
+	{"search", "[-limit n] [-offset n] <query>", "search the titles and descriptions of notes", search},
+	{"import", "[-chunked] <file|->",
+		"create the notes of a JSON or YAML file written by export, all or none of them unless -chunked", importNotes},
+	{"export", "[-f file]", "write every note as JSON, or YAML with -o yaml", exportNotes},
+}
+
+// flags returns the flag set of a command, its errors are returned rather than
+// exiting.
+func flags(name string, e env) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	return fs
+}
+
+// noteIDArg parses the only argument left by fs as a note id.
+func noteIDArg(fs *flag.FlagSet) (uint64, error) {
+	if fs.NArg() != 1 {
+		return 0, fmt.Errorf("%w: want one note id", ErrUsage)
+	}
+	id, err := strconv.ParseUint(fs.Arg(0), 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%w: bad note id %q", ErrUsage, fs.Arg(0))
+	}
+	return id, nil
+}
+
+func splitTags(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func list(ctx context.Context, e env, args []string) error {
+	var (
+		filter models.NotesFilter
+		tags   string
+		limit  int
+	)
+	fs := flags("list", e)
+	fs.DurationVar(&filter.Interval, "interval", 0, "only notes due within the interval, such as 1h")
+	fs.StringVar(&tags, "tags", "", "only notes with any of the comma-separated tags")
+	fs.BoolVar(&filter.MatchAllTags, "all-tags", false, "only notes with all of the tags")
+	fs.StringVar(&filter.SortBy, "sort", "", "sort by id, date_notify or date_added")
+	fs.BoolVar(&filter.Descending, "desc", false, "sort in descending order")
+	fs.IntVar(&limit, "limit", 0, "list at most n notes, 0 lists all")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	filter.Tags = splitTags(tags)
+
+	notes := make([]models.Note, 0, 16)
+	it := e.api.Notes(ctx, filter)
+	for (limit <= 0 || len(notes) < limit) && it.Next() {
+		notes = append(notes, it.Note())
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	return e.out.notes(notes)
+}
+
+func get(ctx context.Context, e env, args []string) error {
+	fs := flags("get", e)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := noteIDArg(fs)
+	if err != nil {
+		return err
+	}
+	note, err := e.api.GetNote(ctx, id)
+	if err != nil {
+		return err
+	}
+	return e.out.note(note)
+}
+
+func create(ctx context.Context, e env, args []string) error {
+	var (
+		note models.Note
+		tags string
+	)
+	fs := flags("create", e)
+	fs.StringVar(&note.Title, "title", "", "title of the note")
+	fs.StringVar(&note.Description, "description", "", "description of the note")
+	fs.StringVar(&tags, "tags", "", "comma-separated tags")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if note.Title == "" || fs.NArg() > 0 {
+		return fmt.Errorf("%w: create needs -title and no arguments", ErrUsage)
+	}
+	note.Tags = splitTags(tags)
+
+	created, err := e.api.CreateNote(ctx, note)
+	if err != nil {
+		return err
+	}
+	return e.out.note(created)
+}
+
+func edit(ctx context.Context, e env, args []string) error {
+	fs := flags("edit", e)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := noteIDArg(fs)
+	if err != nil {
+		return err
+	}
+	note, err := e.api.GetNote(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	b, err := marshalEditDoc(note)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp("", "notesctl-*.yaml")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	b, err = editFile(f.Name())
+	if err != nil {
+		return err
+	}
+	edited, fields, err := editedNote(note, b)
+	if errors.Is(err, ErrEditAborted) {
+		fmt.Fprintln(e.stderr, err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// The version makes the update fail if the note changed during the edit.
+	if err := e.api.UpdateNoteFields(ctx, edited, fields...); err != nil {
+		return err
+	}
+	note, err = e.api.GetNote(ctx, id)
+	if err != nil {
+		return err
+	}
+	return e.out.note(note)
+}
+
+func del(ctx context.Context, e env, args []string) error {
+	var version uint64
+	fs := flags("delete", e)
+	fs.Uint64Var(&version, "version", 0, "delete the note only at this version")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := noteIDArg(fs)
+	if err != nil {
+		return err
+	}
+	if err := e.api.DeleteNote(ctx, id, version); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stderr, "deleted note %d\n", id)
+	return nil
+}
+
+func refresh(ctx context.Context, e env, args []string) error {
+	fs := flags("refresh", e)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	id, err := noteIDArg(fs)
+	if err != nil {
+		return err
+	}
+	note, deleted, err := e.api.RefreshNote(ctx, id)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		fmt.Fprintf(e.stderr, "note %d was deleted, its delay grew too long\n", id)
+		return nil
+	}
+	return e.out.note(note)
+}
+
+func search(ctx context.Context, e env, args []string) error {
+	var q models.SearchQuery
+	fs := flags("search", e)
+	fs.IntVar(&q.Limit, "limit", 0, "at most n results, the server decides by default")
+	fs.IntVar(&q.Offset, "offset", 0, "skip the first n results")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	q.Query = strings.Join(fs.Args(), " ")
+	if q.Query == "" {
+		return fmt.Errorf("%w: search needs a query", ErrUsage)
+	}
+	page, err := e.api.Search(ctx, q)
+	if err != nil {
+		return err
+	}
+	return e.out.search(page)
+}
+
+// maxBatchSize mirrors app.MaxBatchSize.
+const maxBatchSize = 1000
+
+func importNotes(ctx context.Context, e env, args []string) error {
+	var chunked bool
+	fs := flags("import", e)
+	fs.BoolVar(&chunked, "chunked", false,
+		"import more notes than a batch holds in batches of 1000, those before a failed batch stay imported")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: want a file, or - for stdin", ErrUsage)
+	}
+
+	var (
+		b   []byte
+		err error
+	)
+	if fs.Arg(0) == "-" {
+		b, err = io.ReadAll(e.stdin)
+	} else {
+		b, err = os.ReadFile(fs.Arg(0))
+	}
+	if err != nil {
+		return err
+	}
+	notes, err := decodeNotes(b)
+	if err != nil {
+		return fmt.Errorf("can not read notes: %w", err)
+	}
+
+	if len(notes) == 0 {
+		fmt.Fprintln(e.stderr, "no notes to import")
+		return nil
+	}
+	if len(notes) > maxBatchSize && !chunked {
+		return fmt.Errorf("%w: %d notes are more than the %d of one atomic import, use -chunked",
+			ErrUsage, len(notes), maxBatchSize)
+	}
+	// Notes are created anew in atomic batches, so a failed batch creates none
+	// of its notes. The server sets their ids, versions and added dates.
+	created := make([]models.Note, len(notes))
+	for i, n := range notes {
+		created[i] = models.Note{
+			Title:       n.Title,
+			Description: n.Description,
+			DateNotify:  n.DateNotify,
+			Delay:       n.Delay,
+			Tags:        n.Tags,
+		}
+	}
+	for start := 0; start < len(created); start += maxBatchSize {
+		batch := created[start:min(start+maxBatchSize, len(created))]
+		if _, err := e.api.ImportNotes(ctx, batch, models.BatchAtomic); err != nil {
+			return fmt.Errorf("imported %d of %d notes, the batch of notes %d to %d failed: %w",
+				start, len(notes), start+1, start+len(batch), err)
+		}
+	}
+	fmt.Fprintf(e.stderr, "imported %d notes\n", len(notes))
+	return nil
+}
+
+func exportNotes(ctx context.Context, e env, args []string) error {
+	var path string
+	fs := flags("export", e)
+	fs.StringVar(&path, "f", "", "write to the file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	notes, err := e.api.Notes(ctx, models.NotesFilter{}).All()
+	if err != nil {
+		return err
+	}
+
+	out := e.out
+	if out.format == formatTable {
+		out.format = formatJSON
+	}
+	if path == "" {
+		return out.encode(notes)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	out.w = f
+	err = out.encode(notes)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		fmt.Fprintf(e.stderr, "exported %d notes to %s\n", len(notes), path)
+	}
+	return err
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"notes/internal/pkg/clients"
+	notesgrpcclient "notes/internal/pkg/clients/notesGRPCclient"
+	"notes/internal/pkg/clients/notesclient"
+	"notes/internal/pkg/config"
+	"notes/internal/pkg/models"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrNoProfile = errors.New("no such profile")
+
+// Config is the file of connection profiles:
+//
+//	current: local
+//	profiles:
+//	  local:
+//	    url: http://localhost:3052
+//	  prod:
+//	    protocol: grpc
+//	    grpc: [notes-1:3054, notes-2:3054]
+//	    tls:
+//	      enabled: true
+//	      caFile: /etc/notesctl/ca.pem
+type Config struct {
+	// Current is the profile used without -profile.
+	Current  string             `yaml:"current"`
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+type Profile struct {
+	// Protocol is rest, the default, or grpc.
+	Protocol string `yaml:"protocol"`
+	// URL is the base URL of the REST API.
+	URL string `yaml:"url"`
+	// GRPC are the addresses of the gRPC servers, calls are balanced over them.
+	GRPC []string `yaml:"grpc"`
+	// APIKey and Token authenticate the calls, NOTES_API_KEY and NOTES_TOKEN override them.
+	APIKey  string        `yaml:"apiKey"`
+	Token   string        `yaml:"token"`
+	Timeout time.Duration `yaml:"timeout"`
+	TLS     config.TLS    `yaml:"tls"`
+}
+
+const (
+	protocolREST = "rest"
+	protocolGRPC = "grpc"
+)
+
+// defaultProfile reaches the notes API of config/local.yaml.
+var defaultProfile = Profile{
+	Protocol: protocolREST,
+	URL:      "http://localhost:3052",
+	GRPC:     []string{"localhost:3054"},
+}
+
+// defaultConfigPath is notesctl/config.yaml in the user config directory.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "notesctl.yaml"
+	}
+	return filepath.Join(dir, "notesctl", "config.yaml")
+}
+
+// loadProfile reads the profile name, the current one when it is empty, from the
+// file at path. Without the file, the default profile is used unless the file
+// or the profile were asked for.
+func loadProfile(path, name string, required bool) (Profile, error) {
+	var cfg Config
+	b, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist) && !required && name == "":
+		return withEnv(defaultProfile), nil
+	case err != nil:
+		return Profile{}, err
+	}
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return Profile{}, fmt.Errorf("can not parse %s: %w", path, err)
+	}
+
+	if name == "" {
+		name = cfg.Current
+	}
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("%w %q in %s", ErrNoProfile, name, path)
+	}
+	return withEnv(p), nil
+}
+
+func withEnv(p Profile) Profile {
+	if key := os.Getenv("NOTES_API_KEY"); key != "" {
+		p.APIKey = key
+	}
+	if token := os.Getenv("NOTES_TOKEN"); token != "" {
+		p.Token = token
+	}
+	return p
+}
+
+// api is what the commands need from notesclient and notesGRPCclient.
+type api interface {
+	GetNote(ctx context.Context, id uint64) (models.Note, error)
+	Notes(ctx context.Context, filter models.NotesFilter) *clients.NotesIterator
+	CreateNote(ctx context.Context, note models.Note) (models.Note, error)
+	ImportNotes(ctx context.Context, notes []models.Note, mode models.BatchMode) ([]models.BatchResult, error)
+	UpdateNoteFields(ctx context.Context, note models.Note, fields ...models.NoteField) error
+	DeleteNote(ctx context.Context, id uint64, version uint64) error
+	RefreshNote(ctx context.Context, id uint64) (_ models.Note, deleted bool, err error)
+	Search(ctx context.Context, q models.SearchQuery) (models.SearchPage, error)
+	Close() error
+}
+
+type restAPI struct {
+	*notesclient.NotesClient
+}
+
+func (restAPI) Close() error {
+	return nil
+}
+
+func newAPI(p Profile) (api, error) {
+	switch p.Protocol {
+	case "", protocolREST:
+		if p.URL == "" {
+			return nil, errors.New("profile has no REST URL")
+		}
+		opts := []notesclient.Option{
+			notesclient.WithBaseURL(p.URL),
+			notesclient.WithAPIKey(p.APIKey),
+			notesclient.WithBearerToken(p.Token),
+		}
+		if p.Timeout > 0 {
+			opts = append(opts, notesclient.WithTimeout(p.Timeout))
+		}
+		return restAPI{notesclient.New(config.Server{TLS: p.TLS}, opts...)}, nil
+	case protocolGRPC:
+		if len(p.GRPC) == 0 {
+			return nil, errors.New("profile has no gRPC address")
+		}
+		opts := []notesgrpcclient.Option{
+			notesgrpcclient.WithAPIKey(p.APIKey),
+			notesgrpcclient.WithBearerToken(p.Token),
+		}
+		if len(p.GRPC) > 1 {
+			opts = append(opts, notesgrpcclient.WithAddresses(p.GRPC...))
+		}
+		if p.Timeout > 0 {
+			opts = append(opts, notesgrpcclient.WithCallTimeout(p.Timeout))
+		}
+		return notesgrpcclient.New(config.GRPCServer{Host: p.GRPC[0], TLS: p.TLS}, opts...)
+	}
+	return nil, fmt.Errorf("unknown protocol %q, want rest or grpc", p.Protocol)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"notes/internal/pkg/models"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrEditAborted = errors.New("edit aborted, the note is unchanged")
+
+// editDoc is the part of a note edit changes, written for people rather than
+// the API: durations such as 20m and tags in one line.
+type editDoc struct {
+	Title       string        `yaml:"title"`
+	Description string        `yaml:"description"`
+	DateNotify  time.Time     `yaml:"dateNotify"`
+	Delay       time.Duration `yaml:"delay"`
+	Tags        []string      `yaml:"tags,flow"`
+}
+
+func toEditDoc(n models.Note) editDoc {
+	return editDoc{
+		Title:       n.Title,
+		Description: n.Description,
+		DateNotify:  n.DateNotify,
+		Delay:       n.Delay,
+		Tags:        n.Tags,
+	}
+}
+
+func marshalEditDoc(n models.Note) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# Note %d, version %d. Save and quit to update it,\n", n.ID, n.Version)
+	fmt.Fprintf(&b, "# an unchanged or empty file leaves it as it is.\n")
+	e := yaml.NewEncoder(&b)
+	e.SetIndent(2)
+	if err := e.Encode(toEditDoc(n)); err != nil {
+		return nil, err
+	}
+	if err := e.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// editedNote applies the edited document b to n and returns the changed fields.
+func editedNote(n models.Note, b []byte) (models.Note, []models.NoteField, error) {
+	var doc editDoc
+	if len(bytes.TrimSpace(b)) == 0 {
+		return n, nil, ErrEditAborted
+	}
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return n, nil, fmt.Errorf("can not parse the edited note: %w", err)
+	}
+
+	before := toEditDoc(n)
+	var fields []models.NoteField
+	if doc.Title != before.Title {
+		fields = append(fields, models.FieldTitle)
+	}
+	if doc.Description != before.Description {
+		fields = append(fields, models.FieldDescription)
+	}
+	if !doc.DateNotify.Equal(before.DateNotify) {
+		fields = append(fields, models.FieldDateNotify)
+	}
+	if doc.Delay != before.Delay {
+		fields = append(fields, models.FieldDelay)
+	}
+	if !slices.Equal(doc.Tags, before.Tags) {
+		fields = append(fields, models.FieldTags)
+	}
+	if len(fields) == 0 {
+		return n, nil, ErrEditAborted
+	}
+
+	n.Title, n.Description, n.DateNotify, n.Delay, n.Tags =
+		doc.Title, doc.Description, doc.DateNotify, doc.Delay, doc.Tags
+	return n, fields, nil
+}
+
+// editFile opens path in $VISUAL, $EDITOR or vi and returns its content once
+// the editor exits.
+func editFile(path string) ([]byte, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	// The editor may have arguments, such as "code --wait".
+	args := append(strings.Fields(editor), path)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("editor %s failed: %w", editor, err)
+	}
+	return os.ReadFile(path)
+}
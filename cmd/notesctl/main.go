@@ -0,0 +1,104 @@
+// Command notesctl manages notes through the REST or gRPC API of the notes
+// service, using the connection profiles of its config file.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+var (
+	configPath string
+	profile    string
+	output     string
+	protocol   string
+)
+
+func init() {
+	flag.StringVar(&configPath, "config", defaultConfigPath(), "config file of the connection profiles")
+	flag.StringVar(&profile, "profile", "", "connection profile, the current one of the config by default")
+	flag.StringVar(&output, "o", formatTable, "output format: table, json or yaml")
+	flag.StringVar(&protocol, "protocol", "", "rest or grpc, overrides the profile")
+	flag.Usage = usage
+}
+
+func usage() {
+	w := flag.CommandLine.Output()
+	fmt.Fprintf(w, "Usage: notesctl [flags] <command> [command flags] [args]\n\nCommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", c.name, c.help)
+		fmt.Fprintf(w, "  %-8s   notesctl %s %s\n", "", c.name, c.args)
+	}
+	fmt.Fprintf(w, "\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Parse()
+	os.Exit(run())
+}
+
+func run() int {
+	if flag.NArg() == 0 || !validFormat(output) {
+		flag.Usage()
+		return 2
+	}
+	name, args := flag.Arg(0), flag.Args()[1:]
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == name {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "notesctl: unknown command %q\n\n", name)
+		flag.Usage()
+		return 2
+	}
+
+	var required bool
+	flag.Visit(func(f *flag.Flag) {
+		required = required || f.Name == "config"
+	})
+	p, err := loadProfile(configPath, profile, required)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "notesctl:", err)
+		return 1
+	}
+	if protocol != "" {
+		p.Protocol = protocol
+	}
+	a, err := newAPI(p)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "notesctl:", err)
+		return 1
+	}
+	defer a.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	e := env{
+		api:    a,
+		out:    printer{w: os.Stdout, format: output},
+		stdin:  os.Stdin,
+		stderr: os.Stderr,
+	}
+	err = cmd.run(ctx, e, args)
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, ErrUsage):
+		fmt.Fprintf(os.Stderr, "notesctl: %s\nUsage: notesctl %s %s\n", err, cmd.name, cmd.args)
+		return 2
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "notesctl:", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"notes/internal/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditedNote(t *testing.T) {
+	tm := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	note := models.Note{ID: 3, Title: "milk", Description: "2l", DateNotify: tm,
+		Delay: time.Minute * 20, Tags: []string{"home"}, Version: 5}
+
+	b, err := marshalEditDoc(note)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "delay: 20m0s")
+
+	_, _, err = editedNote(note, b)
+	assert.ErrorIs(t, err, ErrEditAborted)
+
+	b = bytes.Replace(b, []byte("title: milk"), []byte("title: bread"), 1)
+	b = bytes.Replace(b, []byte("delay: 20m0s"), []byte("delay: 1h"), 1)
+	b = bytes.Replace(b, []byte("tags: [home]"), []byte("tags: []"), 1)
+	edited, fields, err := editedNote(note, b)
+	require.NoError(t, err)
+	assert.Equal(t, []models.NoteField{models.FieldTitle, models.FieldDelay, models.FieldTags}, fields)
+	assert.Equal(t, "bread", edited.Title)
+	assert.Equal(t, time.Hour, edited.Delay)
+	assert.Empty(t, edited.Tags)
+	assert.Equal(t, uint64(5), edited.Version)
+}
+
+func TestExportImport(t *testing.T) {
+	tm := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	notes := []models.Note{
+		{ID: 1, Title: "milk", DateNotify: tm, Delay: time.Minute * 20, Tags: []string{"home", "shop"}},
+		{ID: 2, Title: "call: mom", Description: "line one\nline two"},
+	}
+
+	for _, format := range []string{formatJSON, formatYAML} {
+		var b bytes.Buffer
+		require.NoError(t, printer{w: &b, format: format}.encode(notes))
+		if format == formatYAML {
+			assert.Contains(t, b.String(), "dateNotify:")
+		}
+
+		got, err := decodeNotes(b.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, notes, got, format)
+	}
+}
+
+// batchAPI records the batches of ImportNotes, the other calls are not made.
+// It fails the batch failAt with err.
+type batchAPI struct {
+	api
+	batches [][]models.Note
+	mode    models.BatchMode
+	failAt  int
+	err     error
+}
+
+func (a *batchAPI) ImportNotes(_ context.Context, notes []models.Note,
+	mode models.BatchMode,
+) ([]models.BatchResult, error) {
+	a.batches, a.mode = append(a.batches, notes), mode
+	if len(a.batches)-1 == a.failAt {
+		return nil, a.err
+	}
+	return make([]models.BatchResult, len(notes)), nil
+}
+
+func TestImport(t *testing.T) {
+	tm := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	file := `[{"id": 1, "title": "milk", "dateNotify": "2026-10-19T09:00:00Z", "delay": 1200000000000,
+		"tags": ["home"], "version": 3}, {"id": 2, "title": "bread"}]`
+
+	a := &batchAPI{failAt: -1}
+	var stderr bytes.Buffer
+	e := env{api: a, stdin: bytes.NewBufferString(file), stderr: &stderr}
+	require.NoError(t, importNotes(context.Background(), e, []string{"-"}))
+	assert.Equal(t, models.BatchAtomic, a.mode)
+	assert.Equal(t, [][]models.Note{{
+		{Title: "milk", DateNotify: tm, Delay: time.Minute * 20, Tags: []string{"home"}},
+		{Title: "bread"},
+	}}, a.batches)
+	assert.Equal(t, "imported 2 notes\n", stderr.String())
+
+	a.batches, a.failAt, a.err = nil, 0, errors.New("batch item 1: quota exceeded")
+	e.stdin = bytes.NewBufferString(file)
+	err := importNotes(context.Background(), e, []string{"-"})
+	assert.ErrorIs(t, err, a.err)
+	assert.Contains(t, err.Error(), "imported 0 of 2 notes")
+
+	assert.ErrorIs(t, importNotes(context.Background(), e, nil), ErrUsage)
+}
+
+func TestImportChunked(t *testing.T) {
+	notes := make([]models.Note, maxBatchSize*2+500)
+	for i := range notes {
+		notes[i].Title = "note"
+	}
+	var file bytes.Buffer
+	require.NoError(t, printer{w: &file, format: formatJSON}.encode(notes))
+
+	// Without -chunked, nothing is sent rather than failing on the server.
+	a := &batchAPI{failAt: -1}
+	e := env{api: a, stdin: bytes.NewReader(file.Bytes()), stderr: io.Discard}
+	err := importNotes(context.Background(), e, []string{"-"})
+	assert.ErrorIs(t, err, ErrUsage)
+	assert.Contains(t, err.Error(), "use -chunked")
+	assert.Empty(t, a.batches)
+
+	e.stdin = bytes.NewReader(file.Bytes())
+	require.NoError(t, importNotes(context.Background(), e, []string{"-chunked", "-"}))
+	require.Len(t, a.batches, 3)
+	assert.Len(t, a.batches[0], maxBatchSize)
+	assert.Len(t, a.batches[2], 500)
+
+	// The batches before a failed one stay imported.
+	a.batches, a.failAt, a.err = nil, 1, errors.New("quota exceeded")
+	e.stdin = bytes.NewReader(file.Bytes())
+	err = importNotes(context.Background(), e, []string{"-chunked", "-"})
+	assert.ErrorIs(t, err, a.err)
+	assert.Contains(t, err.Error(), "imported 1000 of 2500 notes, the batch of notes 1001 to 2000 failed")
+}
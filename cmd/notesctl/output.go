@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"notes/internal/pkg/models"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+func validFormat(f string) bool {
+	return f == formatTable || f == formatJSON || f == formatYAML
+}
+
+// printer writes results as a table, or as JSON and YAML documents with the
+// members of the REST API.
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func (p printer) notes(notes []models.Note) error {
+	if p.format != formatTable {
+		return p.encode(notes)
+	}
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTITLE\tNOTIFY\tDELAY\tTAGS\tVERSION")
+	for _, n := range notes {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%d\n",
+			n.ID, truncate(n.Title, 40), formatTime(n.DateNotify), n.Delay, strings.Join(n.Tags, ","), n.Version)
+	}
+	return tw.Flush()
+}
+
+func (p printer) note(n models.Note) error {
+	if p.format != formatTable {
+		return p.encode(n)
+	}
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID:\t%d\n", n.ID)
+	fmt.Fprintf(tw, "Title:\t%s\n", n.Title)
+	fmt.Fprintf(tw, "Description:\t%s\n", n.Description)
+	fmt.Fprintf(tw, "Added:\t%s\n", formatTime(n.DateAdded))
+	fmt.Fprintf(tw, "Notify:\t%s\n", formatTime(n.DateNotify))
+	fmt.Fprintf(tw, "Delay:\t%s\n", n.Delay)
+	fmt.Fprintf(tw, "Tags:\t%s\n", strings.Join(n.Tags, ", "))
+	fmt.Fprintf(tw, "Version:\t%d\n", n.Version)
+	if n.AcknowledgedAt != nil {
+		fmt.Fprintf(tw, "Acknowledged:\t%s\n", formatTime(*n.AcknowledgedAt))
+	}
+	return tw.Flush()
+}
+
+func (p printer) search(page models.SearchPage) error {
+	if p.format != formatTable {
+		return p.encode(page)
+	}
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tRANK\tTITLE\tSNIPPET")
+	for _, r := range page.Results {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", r.Note.ID, strconv.FormatFloat(r.Rank, 'f', 3, 64),
+			truncate(r.Note.Title, 40), truncate(strings.Join(strings.Fields(r.Snippet), " "), 60))
+	}
+	if page.NextOffset != 0 {
+		fmt.Fprintf(tw, "\nMore results with -offset %d\n", page.NextOffset)
+	}
+	return tw.Flush()
+}
+
+func (p printer) encode(v interface{}) error {
+	if p.format == formatYAML {
+		return encodeYAML(p.w, v)
+	}
+	e := json.NewEncoder(p.w)
+	e.SetIndent("", "  ")
+	return e.Encode(v)
+}
+
+// encodeYAML writes v as YAML with its JSON member names and order, by reading
+// its JSON encoding, which is YAML, as block style YAML.
+func encodeYAML(w io.Writer, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+	blockStyle(&doc)
+
+	e := yaml.NewEncoder(w)
+	e.SetIndent(2)
+	if err := e.Encode(&doc); err != nil {
+		return err
+	}
+	return e.Close()
+}
+
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
+
+// decodeNotes reads a JSON or YAML list of notes, as written by export.
+func decodeNotes(b []byte) ([]models.Note, error) {
+	var notes []models.Note
+	if json.Valid(b) {
+		return notes, json.Unmarshal(b, &notes)
+	}
+
+	var doc interface{}
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	// The YAML members are those of the JSON encoding.
+	jb, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return notes, json.Unmarshal(jb, &notes)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
@@ -6,7 +6,10 @@ import (
 	"log"
 	notesgrpcclient "notes/internal/pkg/clients/notesGRPCclient"
 	"notes/internal/pkg/config"
+	"notes/internal/pkg/health"
 	"notes/internal/pkg/logger"
+	"notes/internal/pkg/metrics"
+	"notes/internal/pkg/tracing"
 	"notes/internal/publisher"
 	"notes/internal/publisher/kafkapublisher"
 	"os/signal"
@@ -32,12 +35,20 @@ func main() {
 		log.Fatalf("can not set up logger error: %s", err.Error())
 	}
 
+	shutdownTracing, err := tracing.New(context.Background(), cfg.Tracing, "publisher")
+	if err != nil {
+		logg.Fatalf("can not set up tracing error %s", err)
+	}
+
 	kp, err := kafkapublisher.New(cfg.Kafka)
 	if err != nil {
 		logg.Fatalf("can not set up kafka publisher error %w", err)
 	}
 
-	gcf, err := notesgrpcclient.New(cfg.GRPCServer)
+	gcf, err := notesgrpcclient.New(cfg.GRPCServer,
+		notesgrpcclient.WithAPIKey(cfg.Auth.APIKey),
+		notesgrpcclient.WithBearerToken(cfg.Auth.Token),
+	)
 	if err != nil {
 		logg.Fatalf("can not set up grpc fetcher error %w", err)
 	}
@@ -46,33 +57,61 @@ func main() {
 	if err != nil {
 		logg.Fatal("can not set up kafka publisher", err)
 	}
-	logg.Info("Started publisher")
 
 	ctx, cancel := signal.NotifyContext(context.Background(),
 		syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM, syscall.SIGHUP)
 	defer cancel()
 
+	h := health.New(time.Second * 2)
+	h.AddReadinessCheck("kafka", kp.Ping)
+	h.AddReadinessCheck("notes_api", gcf.Check)
+
+	m := metrics.New(cfg.Metrics)
+	m.Handle("/healthz", h.LiveHandler())
+	m.Handle("/readyz", h.ReadyHandler())
+	if cfg.Metrics.Port != "" {
+		go func() {
+			logg.Info("started metrics on ", cfg.Metrics.Host+cfg.Metrics.Port)
+			if err := m.Start(ctx); err != nil {
+				logg.Error("can not start metrics server", err)
+			}
+		}()
+	}
+
+	ctxR, cancelR := context.WithTimeout(ctx, time.Minute)
+	defer cancelR()
+	if err := h.WaitReady(ctxR, time.Second*3); err != nil {
+		logg.Fatalf("publisher dependencies are not ready error %s", err)
+	}
+	logg.Info("Started publisher")
+
 	err = kb.Publish(ctx)
 	if err != nil {
 		logg.Error("can not publish messages", err)
 	}
 
+	// Publishes run one at a time, so that shutting down waits for the current one.
+	t := time.NewTicker(time.Minute * 3)
+	defer t.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			logg.Info("Shutdown publisher")
-			cancel()
 			ctxS, cancelS := context.WithTimeout(context.Background(), time.Second*5)
 			defer cancelS()
-			kb.Shutdown(ctxS)
-			break
-		case <-time.After(time.Minute * 3):
-			go func() {
-				err = kb.Publish(ctx)
-				if err != nil {
-					logg.Error("can not publish messages", err)
-				}
-			}()
+			if err := kb.Shutdown(ctxS); err != nil {
+				logg.Error("can not shut down kafka publisher", err)
+			}
+			if err := gcf.Close(); err != nil {
+				logg.Error("can not close notes API connection", err)
+			}
+			m.Shutdown(ctxS)
+			shutdownTracing(ctxS)
+			return
+		case <-t.C:
+			if err := kb.Publish(ctx); err != nil {
+				logg.Error("can not publish messages", err)
+			}
 		}
 	}
 }
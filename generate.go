@@ -1,3 +1,5 @@
-//go:generate protoc --go_out=. --go-grpc_out=. ./api/notes.proto
+//go:generate protoc -I . -I ./api/third_party --go_out=. --go-grpc_out=. --grpc-gateway_out=. --openapiv2_out=. ./api/notes.proto
+//go:generate protoc -I . -I ./api/third_party --connect-go_out=. --connect-go_opt=module=notes,Mapi/notes.proto=notes/internal/notes/server/grpcserver/pb ./api/notes.proto
+//go:generate protoc -I . --go_out=. --go-grpc_out=. ./api/admin.proto
 
 package gen
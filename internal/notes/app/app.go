@@ -2,83 +2,422 @@ package app
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/auth"
+	"notes/internal/pkg/config"
 	"notes/internal/pkg/models"
+	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("notes/internal/notes/app")
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+	DefaultPageSize    = 50
+	MaxPageSize        = 500
+	MaxBatchSize       = 1000
+)
+
+// DefaultDelay is the delay of the notes created without one.
+const DefaultDelay = time.Minute * 20
+
+// MaxRefreshDelay is the longest delay between reminders, RefreshNote deletes
+// notes whose delay would grow beyond it.
+const MaxRefreshDelay = time.Hour * 24 * 365
+
+var (
+	ErrEmptyQuery       = errors.New("search query is empty")
+	ErrEmptyBatch       = errors.New("batch has no items")
+	ErrBatchTooLarge    = errors.New("batch has too many items")
+	ErrInvalidBatchMode = errors.New("invalid batch mode")
+	ErrSnoozeInPast     = errors.New("snooze time is not in the future")
+	// ErrQuotaExceeded is the storage error, which enforces the quotas atomically.
+	ErrQuotaExceeded = storage.ErrQuotaExceeded
 )
 
 type NoteCreater interface {
-	CreateNote(context.Context, models.Note) error
+	// CreateNote returns the note with its id and version.
+	CreateNote(context.Context, models.Note) (models.Note, error)
 }
 
 type NotesGetter interface {
-	GetNotes(context.Context, time.Duration) ([]models.Note, error)
+	GetNotes(context.Context, models.NotesFilter) (models.NotesPage, error)
 }
 
 type NoteDeleter interface {
-	DeleteNote(context.Context, uint64) error
+	// DeleteNote deletes the note with the version, or any version when it is zero.
+	DeleteNote(ctx context.Context, id uint64, version uint64) error
 }
 
 type NoteUpdater interface {
-	UpdateNote(context.Context, models.Note) error
+	// UpdateNote sets the fields of the note, zero values included.
+	UpdateNote(ctx context.Context, note models.Note, fields []models.NoteField) error
 }
 
 type NoteGetter interface {
 	GetNote(context.Context, uint64) (models.Note, error)
 }
 
+type TagsLister interface {
+	ListTags(context.Context) ([]models.TagCount, error)
+}
+
+type NotesSearcher interface {
+	Search(context.Context, models.SearchQuery) (models.SearchPage, error)
+}
+
+// NoteActioner changes the reminder of a note in one atomic operation and
+// returns the changed note.
+type NoteActioner interface {
+	// RefreshNote reschedules the note after its delay and multiplies the delay by
+	// ten, or deletes the note when the delay would exceed maxDelay.
+	RefreshNote(ctx context.Context, id uint64, maxDelay time.Duration) (_ models.Note, deleted bool, err error)
+	SnoozeNote(ctx context.Context, id uint64, until time.Time) (models.Note, error)
+	AcknowledgeNote(ctx context.Context, id uint64) (models.Note, error)
+}
+
+// NoteCounter counts the notes of the caller for its quotas.
+type NoteCounter interface {
+	CountNotes(context.Context) (models.NoteCounts, error)
+}
+
+// BatchWriter changes many notes in one transaction.
+type BatchWriter interface {
+	CreateNotes(context.Context, []models.Note, models.BatchMode) ([]models.BatchResult, error)
+	UpdateNotes(context.Context, []models.NoteUpdate, models.BatchMode) ([]models.BatchResult, error)
+	DeleteNotes(context.Context, []models.NoteRef, models.BatchMode) ([]models.BatchResult, error)
+}
+
 type Storage interface {
 	NoteCreater
 	NotesGetter
 	NoteGetter
 	NoteDeleter
 	NoteUpdater
+	TagsLister
+	NotesSearcher
+	NoteActioner
+	BatchWriter
+	NoteCounter
 }
 
 type NotesApp struct {
-	str Storage
+	str    Storage
+	quotas config.Quotas
 	// cache
 }
 
-func NewApp(str Storage) *NotesApp {
-	return &NotesApp{
+type Option func(*NotesApp)
+
+// WithQuotas limits the notes of every user. Services are not limited.
+func WithQuotas(q config.Quotas) Option {
+	return func(a *NotesApp) {
+		a.quotas = q
+	}
+}
+
+func NewApp(str Storage, opts ...Option) *NotesApp {
+	a := &NotesApp{
 		str: str,
 	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+func (a *NotesApp) CreateNote(ctx context.Context, note models.Note) (_ models.Note, err error) {
+	ctx, span := tracer.Start(ctx, "NotesApp.CreateNote")
+	defer func() { endSpan(span, err) }()
+
+	if err := a.checkQuotas(ctx, 1, 1); err != nil {
+		return models.Note{}, err
+	}
+	return a.str.CreateNote(ctx, newNote(ctx, note, false))
 }
 
-func (a *NotesApp) CreateNote(ctx context.Context, note models.Note) error {
-	note.Delay = time.Minute * 20
+// checkQuotas fails with ErrQuotaExceeded when the user of ctx would go over
+// its quotas with notes more notes and reminders more active reminders. It
+// rejects such requests early, the storage checks the quotas again in the
+// transaction of the write.
+func (a *NotesApp) checkQuotas(ctx context.Context, notes, reminders int) error {
+	if a.quotas.MaxNotes <= 0 && a.quotas.MaxReminders <= 0 {
+		return nil
+	}
+	if _, ok := auth.OwnerFromContext(ctx); !ok {
+		return nil
+	}
+
+	c, err := a.str.CountNotes(ctx)
+	if err != nil {
+		return err
+	}
+	switch {
+	case a.quotas.MaxNotes > 0 && c.Notes+notes > a.quotas.MaxNotes:
+		return fmt.Errorf("%w: at most %d notes", ErrQuotaExceeded, a.quotas.MaxNotes)
+	case a.quotas.MaxReminders > 0 && c.Reminders+reminders > a.quotas.MaxReminders:
+		return fmt.Errorf("%w: at most %d active reminders", ErrQuotaExceeded, a.quotas.MaxReminders)
+	}
+	return nil
+}
+
+// newNote sets the owner, schedule and normalized tags of a note to create. The
+// server schedules notes after DefaultDelay. Imported notes keep their delay and
+// a notify date in the future with keepSchedule, see ImportNotes.
+func newNote(ctx context.Context, note models.Note, keepSchedule bool) models.Note {
+	// Users always own the notes they create, services create notes on behalf of OwnerID.
+	if ownerID, ok := auth.OwnerFromContext(ctx); ok {
+		note.OwnerID = ownerID
+	}
+
+	if len(note.Tags) > 0 {
+		note.Tags = models.NormalizeTags(note.Tags)
+	}
+	if !keepSchedule || note.Delay <= 0 || note.Delay > MaxRefreshDelay {
+		note.Delay = DefaultDelay
+	}
 	note.DateAdded = time.Now()
-	note.DateNotify = note.DateAdded.Add(note.Delay)
+	if !keepSchedule || !note.DateNotify.After(note.DateAdded) {
+		note.DateNotify = note.DateAdded.Add(note.Delay)
+	}
+	return note
+}
+
+func (a *NotesApp) GetNotes(ctx context.Context, filter models.NotesFilter) (_ models.NotesPage, err error) {
+	ctx, span := tracer.Start(ctx, "NotesApp.GetNotes",
+		trace.WithAttributes(
+			attribute.String("notes.interval", filter.Interval.String()),
+			attribute.StringSlice("notes.tags", filter.Tags),
+			attribute.String("notes.sort_by", filter.SortBy),
+			attribute.Int("notes.page_size", filter.PageSize),
+		))
+	defer func() { endSpan(span, err) }()
+
+	if len(filter.Tags) > 0 {
+		filter.Tags = models.NormalizeTags(filter.Tags)
+	}
+	switch {
+	case filter.PageSize <= 0:
+		filter.PageSize = DefaultPageSize
+	case filter.PageSize > MaxPageSize:
+		filter.PageSize = MaxPageSize
+	}
+	return a.str.GetNotes(ctx, filter)
+}
 
-	return a.str.CreateNote(ctx, note)
+func (a *NotesApp) ListTags(ctx context.Context) (_ []models.TagCount, err error) {
+	ctx, span := tracer.Start(ctx, "NotesApp.ListTags")
+	defer func() { endSpan(span, err) }()
+
+	return a.str.ListTags(ctx)
 }
 
-func (a *NotesApp) GetNotes(ctx context.Context, interval time.Duration) ([]models.Note, error) {
-	return a.str.GetNotes(ctx, interval)
+func (a *NotesApp) Search(ctx context.Context, q models.SearchQuery) (_ models.SearchPage, err error) {
+	ctx, span := tracer.Start(ctx, "NotesApp.Search",
+		trace.WithAttributes(attribute.String("notes.query", q.Query)))
+	defer func() { endSpan(span, err) }()
+
+	q.Query = strings.TrimSpace(q.Query)
+	if q.Query == "" {
+		return models.SearchPage{}, ErrEmptyQuery
+	}
+	switch {
+	case q.Limit <= 0:
+		q.Limit = DefaultSearchLimit
+	case q.Limit > MaxSearchLimit:
+		q.Limit = MaxSearchLimit
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+
+	return a.str.Search(ctx, q)
 }
 
-func (a *NotesApp) GetNote(ctx context.Context, id uint64) (models.Note, error) {
+func (a *NotesApp) GetNote(ctx context.Context, id uint64) (_ models.Note, err error) {
+	ctx, span := tracer.Start(ctx, "NotesApp.GetNote", noteIDAttr(id))
+	defer func() { endSpan(span, err) }()
+
 	return a.str.GetNote(ctx, id)
 }
 
-func (a *NotesApp) DeleteNote(ctx context.Context, id uint64) error {
-	return a.str.DeleteNote(ctx, id)
+func (a *NotesApp) DeleteNote(ctx context.Context, id uint64, version uint64) (err error) {
+	ctx, span := tracer.Start(ctx, "NotesApp.DeleteNote", noteIDAttr(id))
+	defer func() { endSpan(span, err) }()
+
+	return a.str.DeleteNote(ctx, id, version)
 }
 
-func (a *NotesApp) UpdateNote(ctx context.Context, note models.Note) error {
-	return a.str.UpdateNote(ctx, note)
+// UpdateNote sets the fields of note. Nil fields update the fields that are set,
+// see models.NonZeroFields.
+func (a *NotesApp) UpdateNote(ctx context.Context, note models.Note, fields []models.NoteField) (err error) {
+	ctx, span := tracer.Start(ctx, "NotesApp.UpdateNote", noteIDAttr(note.ID))
+	defer func() { endSpan(span, err) }()
+
+	note, fields = updatedFields(note, fields)
+	return a.str.UpdateNote(ctx, note, fields)
 }
 
-func (a *NotesApp) RefreshNote(ctx context.Context, note models.Note) error {
-	note, err := a.GetNote(ctx, note.ID)
-	if err != nil {
-		return err
+func updatedFields(note models.Note, fields []models.NoteField) (models.Note, []models.NoteField) {
+	if fields == nil {
+		fields = models.NonZeroFields(note)
+	}
+	for _, f := range fields {
+		if f == models.FieldTags {
+			note.Tags = models.NormalizeTags(note.Tags)
+		}
+	}
+	return note, fields
+}
+
+// CreateNotes creates notes in one transaction. An empty mode is BatchAtomic.
+func (a *NotesApp) CreateNotes(ctx context.Context, notes []models.Note,
+	mode models.BatchMode,
+) (_ []models.BatchResult, err error) {
+	ctx, span := tracer.Start(ctx, "NotesApp.CreateNotes", batchAttr(len(notes), mode))
+	defer func() { endSpan(span, err) }()
+
+	return a.createNotes(ctx, notes, mode, false)
+}
+
+// ImportNotes creates notes like CreateNotes, but they keep their delay and a
+// notify date in the future, such as those of exported notes.
+func (a *NotesApp) ImportNotes(ctx context.Context, notes []models.Note,
+	mode models.BatchMode,
+) (_ []models.BatchResult, err error) {
+	ctx, span := tracer.Start(ctx, "NotesApp.ImportNotes", batchAttr(len(notes), mode))
+	defer func() { endSpan(span, err) }()
+
+	return a.createNotes(ctx, notes, mode, true)
+}
+
+func (a *NotesApp) createNotes(ctx context.Context, notes []models.Note,
+	mode models.BatchMode, keepSchedule bool,
+) (_ []models.BatchResult, err error) {
+	if mode, err = checkBatch(len(notes), mode); err != nil {
+		return nil, err
+	}
+	if err := a.checkQuotas(ctx, len(notes), len(notes)); err != nil {
+		return nil, err
+	}
+	prepared := make([]models.Note, len(notes))
+	for i, n := range notes {
+		prepared[i] = newNote(ctx, n, keepSchedule)
+	}
+	return a.str.CreateNotes(ctx, prepared, mode)
+}
+
+// UpdateNotes applies updates in one transaction. An empty mode is BatchAtomic.
+func (a *NotesApp) UpdateNotes(ctx context.Context, updates []models.NoteUpdate,
+	mode models.BatchMode,
+) (_ []models.BatchResult, err error) {
+	ctx, span := tracer.Start(ctx, "NotesApp.UpdateNotes", batchAttr(len(updates), mode))
+	defer func() { endSpan(span, err) }()
+
+	if mode, err = checkBatch(len(updates), mode); err != nil {
+		return nil, err
+	}
+	prepared := make([]models.NoteUpdate, len(updates))
+	for i, u := range updates {
+		prepared[i].Note, prepared[i].Fields = updatedFields(u.Note, u.Fields)
 	}
-	note.DateNotify = note.DateNotify.Add(note.Delay)
-	note.Delay *= 10
+	return a.str.UpdateNotes(ctx, prepared, mode)
+}
+
+// DeleteNotes deletes notes in one transaction. An empty mode is BatchAtomic.
+func (a *NotesApp) DeleteNotes(ctx context.Context, refs []models.NoteRef,
+	mode models.BatchMode,
+) (_ []models.BatchResult, err error) {
+	ctx, span := tracer.Start(ctx, "NotesApp.DeleteNotes", batchAttr(len(refs), mode))
+	defer func() { endSpan(span, err) }()
+
+	if mode, err = checkBatch(len(refs), mode); err != nil {
+		return nil, err
+	}
+	return a.str.DeleteNotes(ctx, refs, mode)
+}
+
+func checkBatch(n int, mode models.BatchMode) (models.BatchMode, error) {
+	switch mode {
+	case "":
+		mode = models.BatchAtomic
+	case models.BatchAtomic, models.BatchPartial:
+	default:
+		return "", fmt.Errorf("%w: %s", ErrInvalidBatchMode, mode)
+	}
+	switch {
+	case n == 0:
+		return "", ErrEmptyBatch
+	case n > MaxBatchSize:
+		return "", fmt.Errorf("%w: %d, at most %d", ErrBatchTooLarge, n, MaxBatchSize)
+	}
+	return mode, nil
+}
+
+// RefreshNote reschedules a due note, see NoteActioner. deleted is true when the
+// note was deleted because its delay grew beyond MaxRefreshDelay.
+func (a *NotesApp) RefreshNote(ctx context.Context, id uint64) (_ models.Note, deleted bool, err error) {
+	ctx, span := tracer.Start(ctx, "NotesApp.RefreshNote", noteIDAttr(id))
+	defer func() { endSpan(span, err) }()
+
+	return a.str.RefreshNote(ctx, id, MaxRefreshDelay)
+}
+
+// SnoozeNote postpones the reminder of a note until a time in the future.
+func (a *NotesApp) SnoozeNote(ctx context.Context, id uint64, until time.Time) (_ models.Note, err error) {
+	ctx, span := tracer.Start(ctx, "NotesApp.SnoozeNote", noteIDAttr(id))
+	defer func() { endSpan(span, err) }()
 
-	if note.Delay > time.Hour*24*365 {
-		return a.DeleteNote(ctx, note.ID)
+	if !until.After(time.Now()) {
+		return models.Note{}, fmt.Errorf("%w: %s", ErrSnoozeInPast, until.Format(time.RFC3339))
+	}
+	if _, ok := auth.OwnerFromContext(ctx); ok && a.quotas.MaxReminders > 0 {
+		// Snoozing an acknowledged note activates its reminder again.
+		note, err := a.str.GetNote(ctx, id)
+		if err != nil {
+			return models.Note{}, err
+		}
+		if note.AcknowledgedAt != nil {
+			if err := a.checkQuotas(ctx, 0, 1); err != nil {
+				return models.Note{}, err
+			}
+		}
+	}
+	return a.str.SnoozeNote(ctx, id, until)
+}
+
+// AcknowledgeNote stops the reminders of a note until it is snoozed or rescheduled.
+func (a *NotesApp) AcknowledgeNote(ctx context.Context, id uint64) (_ models.Note, err error) {
+	ctx, span := tracer.Start(ctx, "NotesApp.AcknowledgeNote", noteIDAttr(id))
+	defer func() { endSpan(span, err) }()
+
+	return a.str.AcknowledgeNote(ctx, id)
+}
+
+func batchAttr(n int, mode models.BatchMode) trace.SpanStartOption {
+	return trace.WithAttributes(
+		attribute.Int("notes.batch_size", n),
+		attribute.String("notes.batch_mode", string(mode)),
+	)
+}
+
+func noteIDAttr(id uint64) trace.SpanStartOption {
+	return trace.WithAttributes(attribute.Int64("notes.id", int64(id)))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 	}
-	return a.UpdateNote(ctx, note)
+	span.End()
 }
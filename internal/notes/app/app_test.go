@@ -0,0 +1,118 @@
+package app_test
+
+import (
+	"context"
+	"notes/internal/notes/app"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/auth"
+	"notes/internal/pkg/config"
+	"notes/internal/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var nilError error
+
+func TestNoteActions(t *testing.T) {
+	ctx := context.Background()
+	mockStr := new(storage.MockStorage)
+	a := app.NewApp(mockStr)
+
+	t.Run("Test Refresh Note", func(t *testing.T) {
+		refreshed := models.Note{ID: 1, Delay: time.Minute * 10, Version: 2}
+		mockStr.On("RefreshNote", ctx, uint64(1), app.MaxRefreshDelay).Return(refreshed, false, nilError)
+
+		note, deleted, err := a.RefreshNote(ctx, 1)
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+		assert.Equal(t, refreshed, note)
+	})
+
+	t.Run("Test Snooze In Past", func(t *testing.T) {
+		_, err := a.SnoozeNote(ctx, 1, time.Now().Add(-time.Minute))
+		assert.ErrorIs(t, err, app.ErrSnoozeInPast)
+		mockStr.AssertNotCalled(t, "SnoozeNote")
+	})
+}
+
+func TestImportSchedule(t *testing.T) {
+	ctx := context.Background()
+	mockStr := new(storage.MockStorage)
+	a := app.NewApp(mockStr)
+
+	// Imported notes keep their schedule, a past notify date is moved after the delay.
+	future := time.Now().Add(time.Hour).UTC()
+	mockStr.On("CreateNotes", ctx, mock.MatchedBy(func(notes []models.Note) bool {
+		return len(notes) == 3 && notes[0].DateNotify.Equal(future) && notes[0].Delay == time.Hour &&
+			notes[1].DateNotify.Equal(notes[1].DateAdded.Add(time.Hour*2)) && notes[1].Delay == time.Hour*2 &&
+			notes[2].DateNotify.Equal(notes[2].DateAdded.Add(app.DefaultDelay)) && notes[2].Delay == app.DefaultDelay
+	}), models.BatchAtomic).Return([]models.BatchResult{{ID: 1}, {ID: 2}, {ID: 3}}, nilError).Once()
+	// Created notes are always scheduled by the server.
+	mockStr.On("CreateNotes", ctx, mock.MatchedBy(func(notes []models.Note) bool {
+		return len(notes) == 1 && notes[0].Delay == app.DefaultDelay &&
+			notes[0].DateNotify.Equal(notes[0].DateAdded.Add(app.DefaultDelay))
+	}), models.BatchAtomic).Return([]models.BatchResult{{ID: 4}}, nilError).Once()
+
+	_, err := a.ImportNotes(ctx, []models.Note{
+		{Title: "a", DateNotify: future, Delay: time.Hour},
+		{Title: "b", DateNotify: time.Now().Add(-time.Hour), Delay: time.Hour * 2},
+		{Title: "c"},
+	}, models.BatchAtomic)
+	assert.NoError(t, err)
+
+	_, err = a.CreateNotes(ctx, []models.Note{{Title: "d", DateNotify: future, Delay: time.Hour}}, "")
+	assert.NoError(t, err)
+	mockStr.AssertExpectations(t)
+}
+
+func TestBatch(t *testing.T) {
+	ctx := context.Background()
+	mockStr := new(storage.MockStorage)
+	a := app.NewApp(mockStr)
+
+	refs := []models.NoteRef{{ID: 1}}
+	mockStr.On("DeleteNotes", ctx, refs, models.BatchAtomic).Return([]models.BatchResult{{ID: 1}}, nilError)
+
+	res, err := a.DeleteNotes(ctx, refs, "")
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+
+	_, err = a.DeleteNotes(ctx, nil, models.BatchPartial)
+	assert.ErrorIs(t, err, app.ErrEmptyBatch)
+
+	_, err = a.DeleteNotes(ctx, make([]models.NoteRef, app.MaxBatchSize+1), models.BatchAtomic)
+	assert.ErrorIs(t, err, app.ErrBatchTooLarge)
+
+	_, err = a.DeleteNotes(ctx, refs, "all")
+	assert.ErrorIs(t, err, app.ErrInvalidBatchMode)
+	mockStr.AssertExpectations(t)
+}
+
+func TestQuotas(t *testing.T) {
+	mockStr := new(storage.MockStorage)
+	a := app.NewApp(mockStr, app.WithQuotas(config.Quotas{MaxNotes: 10, MaxReminders: 5}))
+	ctx := context.Background()
+	userCtx := auth.NewContext(ctx, auth.Principal{ID: 7, Kind: auth.KindUser})
+
+	mockStr.On("CountNotes", ctx).Return(models.NoteCounts{Notes: 9, Reminders: 5}, nilError).Times(3)
+	mockStr.On("GetNote", ctx, uint64(1)).Return(models.Note{ID: 1, AcknowledgedAt: &time.Time{}}, nilError)
+
+	_, err := a.CreateNote(userCtx, models.Note{Title: "milk"})
+	assert.ErrorIs(t, err, app.ErrQuotaExceeded)
+
+	_, err = a.CreateNotes(userCtx, make([]models.Note, 2), models.BatchAtomic)
+	assert.ErrorIs(t, err, app.ErrQuotaExceeded)
+
+	_, err = a.SnoozeNote(userCtx, 1, time.Now().Add(time.Hour))
+	assert.ErrorIs(t, err, app.ErrQuotaExceeded)
+
+	// Services are not limited.
+	mockStr.On("CreateNote", ctx, mock.Anything).Return(models.Note{ID: 2}, nilError)
+	svcCtx := auth.NewContext(ctx, auth.Principal{Name: "publisher", Kind: auth.KindService})
+	_, err = a.CreateNote(svcCtx, models.Note{Title: "bread"})
+	assert.NoError(t, err)
+	mockStr.AssertExpectations(t)
+}
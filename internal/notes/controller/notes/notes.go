@@ -1,9 +1,11 @@
 package notes
 
 import (
+	"context"
 	"errors"
 	"notes/internal/notes/controller"
 	"notes/internal/notes/server"
+	"notes/internal/notes/server/gateway"
 	"notes/internal/notes/server/ginserver"
 	"notes/internal/notes/server/grpcserver"
 	"notes/internal/pkg/config"
@@ -13,18 +15,23 @@ import (
 const (
 	GRPCAPI = "grpc"
 	RestAPI = "rest"
+	// GatewayAPI is the REST API generated from notes.proto, it calls the GRPCAPI.
+	GatewayAPI = "gateway"
 )
 
 var ErrServerUnspecified = errors.New("server unspecified")
 
-func New(serv string, cfg config.Config, app server.App, logg logger.Logger) (controller.API, error) {
+func New(serv string, cfg config.Config, app server.App, logg logger.Logger,
+	opts ...server.Option,
+) (controller.API, error) {
 	switch serv {
 	case GRPCAPI:
-		s := grpcserver.New(app, logg, cfg.GRPCServer)
-		return s, nil
+		return grpcserver.New(app, logg, cfg.GRPCServer, opts...)
 	case RestAPI:
-		s := ginserver.New(app, cfg.Server, logg)
+		s := ginserver.New(app, cfg.Server, logg, opts...)
 		return s, nil
+	case GatewayAPI:
+		return gateway.New(context.Background(), cfg.Gateway, cfg.GRPCServer)
 	}
 	return nil, ErrServerUnspecified
 }
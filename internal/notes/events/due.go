@@ -0,0 +1,107 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/logger"
+	"notes/internal/pkg/models"
+	"time"
+)
+
+const duePageSize = 500
+
+// NotesLister is implemented by the notes app and storages.
+type NotesLister interface {
+	GetNotes(context.Context, models.NotesFilter) (models.NotesPage, error)
+	GetNote(context.Context, uint64) (models.Note, error)
+}
+
+// PublishDue publishes a NoteDue event when the notify date of a note is reached,
+// until ctx is done. Every poll schedules the notes due within the next two polls,
+// so a slow lookup does not lose any. The note is read again when its timer
+// fires, notes deleted, acknowledged or rescheduled since are not due.
+func (b *Bus) PublishDue(ctx context.Context, notes NotesLister, poll time.Duration, logg logger.Logger) {
+	// scheduled maps note IDs to the notify date their timer was set for.
+	scheduled := make(map[uint64]time.Time)
+	due := make(chan models.Note)
+
+	t := time.NewTicker(poll)
+	defer t.Stop()
+	for {
+		upcoming, err := dueNotes(ctx, notes, poll*2)
+		if err != nil {
+			logg.Errorf("looking up due notes failed: %s", err)
+		}
+		for _, n := range upcoming {
+			if at, ok := scheduled[n.ID]; ok && at.Equal(n.DateNotify) {
+				continue
+			}
+			scheduled[n.ID] = n.DateNotify
+
+			n := n
+			time.AfterFunc(time.Until(n.DateNotify), func() {
+				select {
+				case due <- n:
+				case <-ctx.Done():
+				}
+			})
+		}
+
+	wait:
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case n := <-due:
+				// A later poll rescheduled the note after this timer was set.
+				if !scheduled[n.ID].Equal(n.DateNotify) {
+					continue
+				}
+				delete(scheduled, n.ID)
+
+				current, err := notes.GetNote(ctx, n.ID)
+				switch {
+				case errors.Is(err, storage.ErrNotFound):
+					continue
+				case err != nil:
+					logg.Errorf("looking up due note %d failed: %s", n.ID, err)
+					continue
+				case current.AcknowledgedAt != nil || !current.DateNotify.Equal(n.DateNotify):
+					// A new notify date is scheduled by the next polls.
+					continue
+				}
+				n = current
+				b.Publish(models.NoteEvent{
+					Type:    models.NoteDue,
+					NoteID:  n.ID,
+					OwnerID: n.OwnerID,
+					Note:    &n,
+					Time:    n.DateNotify,
+				})
+			case <-t.C:
+				break wait
+			}
+		}
+	}
+}
+
+func dueNotes(ctx context.Context, notes NotesLister, within time.Duration) ([]models.Note, error) {
+	filter := models.NotesFilter{
+		Interval: within,
+		SortBy:   models.SortByDateNotify,
+		PageSize: duePageSize,
+	}
+	var res []models.Note
+	for {
+		page, err := notes.GetNotes(ctx, filter)
+		if err != nil {
+			return res, err
+		}
+		res = append(res, page.Notes...)
+		if page.NextPageToken == "" {
+			return res, nil
+		}
+		filter.PageToken = page.NextPageToken
+	}
+}
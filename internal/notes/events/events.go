@@ -0,0 +1,222 @@
+// Package events fans note changes out to stream subscribers.
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"notes/internal/pkg/auth"
+	"notes/internal/pkg/config"
+	"notes/internal/pkg/models"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	ErrResumeExpired  = errors.New("resume token is unknown or too old")
+	ErrSlowSubscriber = errors.New("subscriber is too slow")
+	ErrClosed         = errors.New("event bus closed")
+)
+
+var (
+	subscribers = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "notes_event_subscribers",
+		Help: "Number of active note event subscriptions.",
+	})
+	dropped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "notes_event_subscribers_dropped_total",
+		Help: "Number of subscriptions dropped because they could not keep up.",
+	})
+)
+
+// Bus is an in-process event bus. It keeps a history of recent events so that a
+// subscriber can resume after the last event it has seen. Event IDs are only
+// valid for the process that issued them.
+type Bus struct {
+	mu sync.Mutex
+	// epoch tells the IDs of this process from the IDs of a restarted one.
+	epoch   string
+	seq     uint64
+	history []models.NoteEvent
+	// first is the sequence number of history[0].
+	first  uint64
+	size   int
+	buffer int
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+func New(cfg config.Events) *Bus {
+	return &Bus{
+		epoch:  strconv.FormatInt(time.Now().UnixNano(), 36),
+		first:  1,
+		size:   cfg.History,
+		buffer: cfg.Buffer,
+		subs:   make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish assigns the event an ID and delivers it to the matching subscribers.
+// Subscribers whose queue is full are dropped with ErrSlowSubscriber rather than
+// blocking the publisher.
+func (b *Bus) Publish(e models.NoteEvent) models.NoteEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	e.ID = b.id(b.seq)
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	b.history = append(b.history, e)
+	if len(b.history) > b.size {
+		b.history = b.history[len(b.history)-b.size:]
+		b.first = b.seq - uint64(len(b.history)) + 1
+	}
+
+	for sub := range b.subs {
+		if !sub.match(e) {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			dropped.Inc()
+			b.remove(sub, ErrSlowSubscriber)
+		}
+	}
+	return e
+}
+
+// Subscribe returns a subscription to the events accepted by match, or all events
+// when match is nil. A non-empty resumeAfter replays the events published after the
+// event with that ID, ErrResumeExpired is returned when they are no longer kept.
+func (b *Bus) Subscribe(resumeAfter string, match func(models.NoteEvent) bool) (*Subscription, error) {
+	if match == nil {
+		match = func(models.NoteEvent) bool { return true }
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, ErrClosed
+	}
+
+	var replay []models.NoteEvent
+	if resumeAfter != "" {
+		seq, err := b.parseID(resumeAfter)
+		if err != nil {
+			return nil, err
+		}
+		if seq+1 < b.first || seq > b.seq {
+			return nil, ErrResumeExpired
+		}
+		for _, e := range b.history[seq+1-b.first:] {
+			if match(e) {
+				replay = append(replay, e)
+			}
+		}
+	}
+
+	sub := &Subscription{
+		bus:   b,
+		ch:    make(chan models.NoteEvent, b.buffer+len(replay)),
+		match: match,
+	}
+	for _, e := range replay {
+		sub.ch <- e
+	}
+	b.subs[sub] = struct{}{}
+	subscribers.Inc()
+	return sub, nil
+}
+
+// Close ends all subscriptions with ErrClosed.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for sub := range b.subs {
+		b.remove(sub, ErrClosed)
+	}
+}
+
+// remove must be called with b.mu held.
+func (b *Bus) remove(sub *Subscription, err error) {
+	if _, ok := b.subs[sub]; !ok {
+		return
+	}
+	delete(b.subs, sub)
+	subscribers.Dec()
+	sub.err = err
+	close(sub.ch)
+}
+
+func (b *Bus) id(seq uint64) string {
+	return b.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+func (b *Bus) parseID(id string) (uint64, error) {
+	epoch, seq, ok := strings.Cut(id, "-")
+	if !ok || epoch != b.epoch {
+		return 0, ErrResumeExpired
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrResumeExpired, err)
+	}
+	return n, nil
+}
+
+type Subscription struct {
+	bus   *Bus
+	ch    chan models.NoteEvent
+	match func(models.NoteEvent) bool
+	err   error
+}
+
+// Events is closed when the subscription ends, see Err for the reason.
+func (s *Subscription) Events() <-chan models.NoteEvent {
+	return s.ch
+}
+
+// Err returns why the subscription ended, nil if it was closed by the subscriber.
+func (s *Subscription) Err() error {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	return s.err
+}
+
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.bus.remove(s, nil)
+}
+
+// ForContext returns a Subscribe filter that limits a user principal in ctx to the
+// events of their own notes and, when types are given, to events of those types.
+func ForContext(ctx context.Context, types ...models.NoteEventType) func(models.NoteEvent) bool {
+	ownerID, scoped := auth.OwnerFromContext(ctx)
+	return func(e models.NoteEvent) bool {
+		if scoped && e.OwnerID != ownerID {
+			return false
+		}
+		if len(types) == 0 {
+			return true
+		}
+		for _, t := range types {
+			if e.Type == t {
+				return true
+			}
+		}
+		return false
+	}
+}
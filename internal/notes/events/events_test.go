@@ -0,0 +1,131 @@
+package events_test
+
+import (
+	"context"
+	"notes/internal/notes/events"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/config"
+	"notes/internal/pkg/logger"
+	"notes/internal/pkg/models"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBus(t *testing.T) {
+	t.Run("Test Publish And Resume", func(t *testing.T) {
+		b := events.New(config.Events{History: 2, Buffer: 4})
+
+		sub, err := b.Subscribe("", func(e models.NoteEvent) bool { return e.OwnerID == 1 })
+		require.NoError(t, err)
+		defer sub.Close()
+
+		first := b.Publish(models.NoteEvent{Type: models.NoteCreated, NoteID: 1, OwnerID: 1})
+		b.Publish(models.NoteEvent{Type: models.NoteCreated, NoteID: 2, OwnerID: 2})
+		b.Publish(models.NoteEvent{Type: models.NoteDeleted, NoteID: 1, OwnerID: 1})
+
+		e := <-sub.Events()
+		assert.Equal(t, first, e)
+		e = <-sub.Events()
+		assert.Equal(t, models.NoteDeleted, e.Type)
+
+		resumed, err := b.Subscribe(first.ID, nil)
+		require.NoError(t, err)
+		defer resumed.Close()
+		assert.Len(t, resumed.Events(), 2)
+
+		_, err = b.Subscribe("other-1", nil)
+		assert.ErrorIs(t, err, events.ErrResumeExpired)
+
+		b.Publish(models.NoteEvent{Type: models.NoteUpdated, NoteID: 2})
+		_, err = b.Subscribe(first.ID, nil)
+		assert.ErrorIs(t, err, events.ErrResumeExpired)
+	})
+
+	t.Run("Test Slow Subscriber", func(t *testing.T) {
+		b := events.New(config.Events{History: 8, Buffer: 1})
+
+		sub, err := b.Subscribe("", nil)
+		require.NoError(t, err)
+
+		b.Publish(models.NoteEvent{NoteID: 1})
+		b.Publish(models.NoteEvent{NoteID: 2})
+
+		_, ok := <-sub.Events()
+		assert.True(t, ok)
+		_, ok = <-sub.Events()
+		assert.False(t, ok)
+		assert.ErrorIs(t, sub.Err(), events.ErrSlowSubscriber)
+
+		b.Close()
+		_, err = b.Subscribe("", nil)
+		assert.ErrorIs(t, err, events.ErrClosed)
+	})
+}
+
+// dueNotes lists its notes as due, and GetNote returns their current state.
+type dueNotes struct {
+	mu      sync.Mutex
+	listed  []models.Note
+	current map[uint64]models.Note
+}
+
+func (d *dueNotes) GetNotes(context.Context, models.NotesFilter) (models.NotesPage, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	page := models.NotesPage{Notes: d.listed}
+	d.listed = nil
+	return page, nil
+}
+
+func (d *dueNotes) GetNote(_ context.Context, id uint64) (models.Note, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n, ok := d.current[id]
+	if !ok {
+		return models.Note{}, storage.ErrNotFound
+	}
+	return n, nil
+}
+
+func TestPublishDue(t *testing.T) {
+	logg, err := logger.New(logger.EnvLocal)
+	require.NoError(t, err)
+	b := events.New(config.Events{History: 8, Buffer: 8})
+	sub, err := b.Subscribe("", nil)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	at := time.Now().Add(time.Millisecond * 50)
+	later := at.Add(time.Hour)
+	notes := &dueNotes{
+		listed: []models.Note{{ID: 1, DateNotify: at}, {ID: 2, DateNotify: at}, {ID: 3, DateNotify: at},
+			{ID: 4, DateNotify: at}},
+		current: map[uint64]models.Note{
+			1: {ID: 1, DateNotify: at},
+			// Acknowledged, moved to a later date and deleted after their timers were set.
+			2: {ID: 2, DateNotify: at, AcknowledgedAt: &at},
+			3: {ID: 3, DateNotify: later},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go b.PublishDue(ctx, notes, time.Hour, logg)
+
+	select {
+	case e := <-sub.Events():
+		assert.Equal(t, models.NoteDue, e.Type)
+		assert.Equal(t, uint64(1), e.NoteID)
+	case <-time.After(time.Second):
+		t.Fatal("no due event")
+	}
+	select {
+	case e := <-sub.Events():
+		t.Fatalf("unexpected event for note %d", e.NoteID)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
@@ -0,0 +1,146 @@
+// Package idempotency lets clients retry mutating requests safely. A request
+// carrying an idempotency key is executed once, retries with the same key get
+// the stored result of the first one.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"notes/internal/pkg/auth"
+	"notes/internal/pkg/config"
+	"notes/internal/pkg/logger"
+	"strconv"
+	"time"
+)
+
+const (
+	// HeaderKey is the REST header and, in lower case, the gRPC metadata key.
+	HeaderKey = "Idempotency-Key"
+	// HeaderReplayed marks REST responses that were replayed from a stored result.
+	HeaderReplayed = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+)
+
+var (
+	ErrInvalidKey  = errors.New("invalid idempotency key")
+	ErrKeyMismatch = errors.New("idempotency key was used for a different request")
+	ErrInProgress  = errors.New("request with this idempotency key is in progress")
+)
+
+// Record is a request made with an idempotency key and, once it has completed,
+// its response.
+type Record struct {
+	// Scope is the caller the key belongs to, keys of different callers never
+	// clash. Unauthenticated callers are told apart by IP address.
+	Scope string
+	Key   string
+	// Fingerprint identifies the request the key was first used for.
+	Fingerprint []byte
+	// Done is false while the first request is being handled.
+	Done bool
+	// Code, Header and Body are the HTTP status and response, or the encoded
+	// message of a gRPC response.
+	Code   int
+	Header http.Header
+	Body   []byte
+}
+
+// Store persists records. ReserveIdempotencyKey inserts r unless a record with
+// its scope and key that has not expired exists, and returns that record instead.
+type Store interface {
+	ReserveIdempotencyKey(ctx context.Context, r Record, ttl time.Duration) (_ Record, reserved bool, err error)
+	SaveIdempotencyKey(ctx context.Context, r Record) error
+	ReleaseIdempotencyKey(ctx context.Context, r Record) error
+	PurgeIdempotencyKeys(ctx context.Context) (int64, error)
+}
+
+type Keys struct {
+	store Store
+	ttl   time.Duration
+}
+
+func New(store Store, cfg config.Idempotency) *Keys {
+	return &Keys{store: store, ttl: cfg.TTL}
+}
+
+// Begin reserves key for a request from ip with the given fingerprint. When the
+// key has been used for the same request before, its record is returned with
+// replay set and the request must not be executed again. Otherwise the caller
+// must end the request with Finish or Abandon.
+func (k *Keys) Begin(ctx context.Context, key, ip string, fingerprint []byte) (_ Record, replay bool, err error) {
+	if key == "" || len(key) > maxKeyLength {
+		return Record{}, false, fmt.Errorf("%w: must have 1 to %d characters", ErrInvalidKey, maxKeyLength)
+	}
+
+	r := Record{Scope: scope(ctx, ip), Key: key, Fingerprint: fingerprint}
+	stored, reserved, err := k.store.ReserveIdempotencyKey(ctx, r, k.ttl)
+	switch {
+	case err != nil:
+		return Record{}, false, err
+	case reserved:
+		return r, false, nil
+	case string(stored.Fingerprint) != string(fingerprint):
+		return Record{}, false, ErrKeyMismatch
+	case !stored.Done:
+		return Record{}, false, ErrInProgress
+	}
+	return stored, true, nil
+}
+
+// Finish stores the response of a request started with Begin.
+func (k *Keys) Finish(ctx context.Context, r Record) error {
+	r.Done = true
+	return k.store.SaveIdempotencyKey(ctx, r)
+}
+
+// Abandon frees the key of a request that failed, so that it can be retried.
+func (k *Keys) Abandon(ctx context.Context, r Record) error {
+	return k.store.ReleaseIdempotencyKey(ctx, r)
+}
+
+// PurgeExpired deletes expired records every interval until ctx is done.
+func (k *Keys) PurgeExpired(ctx context.Context, interval time.Duration, logg logger.Logger) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			n, err := k.store.PurgeIdempotencyKeys(ctx)
+			if err != nil {
+				logg.Errorf("purging idempotency keys failed: %s", err)
+				continue
+			}
+			logg.Debugf("purged %d idempotency keys\n", n)
+		}
+	}
+}
+
+// Fingerprint hashes the parts of a request, such as its method and body.
+func Fingerprint(parts ...[]byte) []byte {
+	h := sha256.New()
+	for _, p := range parts {
+		_ = binary.Write(h, binary.BigEndian, uint64(len(p)))
+		h.Write(p)
+	}
+	return h.Sum(nil)
+}
+
+// scope identifies users by ID, services by name and the others by ip,
+// like ratelimit.Caller.
+func scope(ctx context.Context, ip string) string {
+	p, ok := auth.FromContext(ctx)
+	switch {
+	case !ok:
+		return "ip:" + ip
+	case p.Kind == auth.KindUser:
+		return string(p.Kind) + ":" + strconv.FormatUint(p.ID, 10)
+	}
+	return string(p.Kind) + ":" + p.Name
+}
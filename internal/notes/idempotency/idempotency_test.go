@@ -0,0 +1,92 @@
+package idempotency_test
+
+import (
+	"context"
+	"notes/internal/notes/idempotency"
+	"notes/internal/pkg/auth"
+	"notes/internal/pkg/config"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memStore keeps records without expiring them.
+type memStore map[string]idempotency.Record
+
+func (m memStore) ReserveIdempotencyKey(_ context.Context, r idempotency.Record,
+	_ time.Duration,
+) (idempotency.Record, bool, error) {
+	if stored, ok := m[r.Scope+"/"+r.Key]; ok {
+		return stored, false, nil
+	}
+	m[r.Scope+"/"+r.Key] = r
+	return r, true, nil
+}
+
+func (m memStore) SaveIdempotencyKey(_ context.Context, r idempotency.Record) error {
+	m[r.Scope+"/"+r.Key] = r
+	return nil
+}
+
+func (m memStore) ReleaseIdempotencyKey(_ context.Context, r idempotency.Record) error {
+	delete(m, r.Scope+"/"+r.Key)
+	return nil
+}
+
+func (m memStore) PurgeIdempotencyKeys(context.Context) (int64, error) {
+	return 0, nil
+}
+
+func TestKeys(t *testing.T) {
+	ctx := context.Background()
+	const ip = "192.0.2.1"
+	fp := idempotency.Fingerprint([]byte("create"), []byte(`{"title":"test"}`))
+
+	t.Run("Test Replay", func(t *testing.T) {
+		k := idempotency.New(memStore{}, config.Idempotency{TTL: time.Hour})
+
+		r, replay, err := k.Begin(ctx, "k1", ip, fp)
+		require.NoError(t, err)
+		assert.False(t, replay)
+
+		_, _, err = k.Begin(ctx, "k1", ip, fp)
+		assert.ErrorIs(t, err, idempotency.ErrInProgress)
+
+		r.Code, r.Body = 201, []byte("created")
+		require.NoError(t, k.Finish(ctx, r))
+
+		r, replay, err = k.Begin(ctx, "k1", ip, fp)
+		require.NoError(t, err)
+		assert.True(t, replay)
+		assert.Equal(t, []byte("created"), r.Body)
+
+		_, _, err = k.Begin(ctx, "k1", ip, idempotency.Fingerprint([]byte("create"), []byte(`{}`)))
+		assert.ErrorIs(t, err, idempotency.ErrKeyMismatch)
+	})
+
+	t.Run("Test Abandon And Scope", func(t *testing.T) {
+		k := idempotency.New(memStore{}, config.Idempotency{TTL: time.Hour})
+
+		r, _, err := k.Begin(ctx, "k1", ip, fp)
+		require.NoError(t, err)
+		require.NoError(t, k.Abandon(ctx, r))
+
+		_, replay, err := k.Begin(ctx, "k1", ip, fp)
+		require.NoError(t, err)
+		assert.False(t, replay)
+
+		user := auth.NewContext(ctx, auth.Principal{ID: 1, Kind: auth.KindUser})
+		_, replay, err = k.Begin(user, "k1", ip, fp)
+		require.NoError(t, err)
+		assert.False(t, replay)
+
+		_, replay, err = k.Begin(ctx, "k1", "192.0.2.2", fp)
+		require.NoError(t, err)
+		assert.False(t, replay)
+
+		_, _, err = k.Begin(ctx, "", ip, fp)
+		assert.ErrorIs(t, err, idempotency.ErrInvalidKey)
+	})
+}
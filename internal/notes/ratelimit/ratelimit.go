@@ -0,0 +1,123 @@
+// Package ratelimit limits the request rate of every caller with token buckets,
+// so that one client can't flood the API.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"notes/internal/pkg/auth"
+	"notes/internal/pkg/config"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type bucketKey struct {
+	route, caller string
+}
+
+// Limiter keeps a token bucket per route and caller.
+type Limiter struct {
+	def    config.Limit
+	routes map[string]config.Limit
+	idle   time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*bucket
+}
+
+func New(cfg config.RateLimit) *Limiter {
+	return &Limiter{
+		def:     cfg.Default,
+		routes:  cfg.Routes,
+		idle:    cfg.Idle,
+		now:     time.Now,
+		buckets: make(map[bucketKey]*bucket),
+	}
+}
+
+// Allow takes a token from the bucket of caller for route. When the bucket is
+// empty it returns false and how long until the next token.
+func (l *Limiter) Allow(route, caller string) (bool, time.Duration) {
+	limit, ok := l.routes[route]
+	if !ok {
+		limit = l.def
+	}
+	if limit.Rate <= 0 {
+		return true, 0
+	}
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = math.Max(1, math.Ceil(limit.Rate))
+	}
+
+	now := l.now()
+	k := bucketKey{route: route, caller: caller}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[k]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[k] = b
+	}
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// PurgeIdle drops the buckets of callers idle for longer than the configured
+// time every interval until ctx is done. A dropped bucket is full again.
+func (l *Limiter) PurgeIdle(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			l.purge()
+		}
+	}
+}
+
+func (l *Limiter) purge() {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for k, b := range l.buckets {
+		if now.Sub(b.last) > l.idle {
+			delete(l.buckets, k)
+		}
+	}
+}
+
+// Caller identifies authenticated callers, whether by token or API key, by
+// their user ID or service name, and the others by ip.
+func Caller(ctx context.Context, ip string) string {
+	p, ok := auth.FromContext(ctx)
+	switch {
+	case !ok:
+		return "ip:" + ip
+	case p.Kind == auth.KindUser:
+		return string(p.Kind) + ":" + strconv.FormatUint(p.ID, 10)
+	}
+	return string(p.Kind) + ":" + p.Name
+}
+
+// RetryAfter is the value of a Retry-After header for wait, in whole seconds.
+func RetryAfter(wait time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(wait.Seconds())))
+}
@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"context"
+	"notes/internal/pkg/auth"
+	"notes/internal/pkg/config"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := New(config.RateLimit{
+		Default: config.Limit{Rate: 1, Burst: 2},
+		Routes:  map[string]config.Limit{"GET /v1/notes": {}},
+		Idle:    time.Minute,
+	})
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		ok, _ := l.Allow("POST /v1/notes", "ip:1")
+		assert.True(t, ok)
+	}
+	ok, wait := l.Allow("POST /v1/notes", "ip:1")
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, wait)
+	assert.Equal(t, "1", RetryAfter(wait))
+
+	// Other callers and routes have their own buckets, routes with zero rate are unlimited.
+	ok, _ = l.Allow("POST /v1/notes", "ip:2")
+	assert.True(t, ok)
+	for i := 0; i < 10; i++ {
+		ok, _ = l.Allow("GET /v1/notes", "ip:1")
+		assert.True(t, ok)
+	}
+
+	now = now.Add(time.Millisecond * 1500)
+	ok, _ = l.Allow("POST /v1/notes", "ip:1")
+	assert.True(t, ok)
+	ok, wait = l.Allow("POST /v1/notes", "ip:1")
+	assert.False(t, ok)
+	assert.Equal(t, time.Millisecond*500, wait)
+
+	now = now.Add(time.Hour)
+	l.purge()
+	assert.Empty(t, l.buckets)
+}
+
+func TestCaller(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "ip:10.0.0.1", Caller(ctx, "10.0.0.1"))
+	assert.Equal(t, "user:7", Caller(auth.NewContext(ctx, auth.Principal{ID: 7, Kind: auth.KindUser}), "10.0.0.1"))
+	assert.Equal(t, "service:publisher",
+		Caller(auth.NewContext(ctx, auth.Principal{Name: "publisher", Kind: auth.KindService}), "10.0.0.1"))
+}
@@ -0,0 +1,289 @@
+// Package connectserver serves the Notes service over the Connect, gRPC-Web and
+// gRPC protocols so that browsers can call it. Like the gateway, it proxies calls
+// to the gRPC server, which logs, authenticates and measures them.
+package connectserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"notes/internal/notes/idempotency"
+	"notes/internal/notes/server/grpcserver/interceptor"
+	"notes/internal/notes/server/grpcserver/pb"
+	"notes/internal/notes/server/grpcserver/pb/pbconnect"
+	"notes/internal/pkg/auth"
+	"notes/internal/pkg/config"
+	"notes/internal/pkg/tlsconfig"
+	"notes/internal/pkg/tracing"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/cors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// forwardedHeaders are passed to the gRPC server as metadata.
+var forwardedHeaders = []string{
+	auth.HeaderAuthorization, auth.HeaderAPIKey, idempotency.HeaderKey, interceptor.HeaderRequestID,
+}
+
+type Server struct {
+	cfg  config.Connect
+	conn *grpc.ClientConn
+	path string
+	h    http.Handler
+	srv  *http.Server
+}
+
+// New returns the Connect API of the gRPC server at grpcCfg, dialed with
+// cfg.ClientTLS. The connection is made lazily, the gRPC server doesn't have to
+// be up yet.
+func New(ctx context.Context, cfg config.Connect, grpcCfg config.GRPCServer) (*Server, error) {
+	creds, err := tlsconfig.ProxyCredentials(cfg.ClientTLS, grpcCfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.DialContext(ctx, grpcCfg.Host+grpcCfg.Port,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithUnaryInterceptor(tracing.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(tracing.StreamClientInterceptor()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	path, h := NewHandler(conn, cfg.AllowedOrigins)
+	mux := http.NewServeMux()
+	mux.Handle(path, h)
+	return &Server{
+		cfg:  cfg,
+		conn: conn,
+		path: path,
+		h:    h,
+		srv: &http.Server{
+			Addr: cfg.Host + cfg.Port,
+			// gRPC clients need HTTP/2, which browsers only use over TLS.
+			Handler:           h2c.NewHandler(mux, &http2.Server{}),
+			ReadHeaderTimeout: time.Second * 5,
+		},
+	}, nil
+}
+
+// Handler returns the handler and the path prefix it serves, for mounting it
+// on another server instead of starting this one.
+func (s *Server) Handler() (string, http.Handler) {
+	return s.path, s.h
+}
+
+// NewHandler returns the Notes service proxied to conn and the path prefix it
+// serves. Browsers from allowedOrigins may call it.
+func NewHandler(conn *grpc.ClientConn, allowedOrigins []string) (string, http.Handler) {
+	path, h := pbconnect.NewNotesHandler(&notesHandler{cl: pb.NewNotesClient(conn)})
+	return path, withCORS(h, allowedOrigins)
+}
+
+// withCORS answers preflight requests and exposes the headers of the Connect
+// and gRPC-Web protocols to scripts.
+func withCORS(h http.Handler, allowedOrigins []string) http.Handler {
+	return cors.New(cors.Options{
+		AllowedOrigins: allowedOrigins,
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders: append([]string{
+			"Content-Type", "Accept", "Accept-Encoding", "Content-Encoding",
+			"Connect-Protocol-Version", "Connect-Timeout-Ms", "Connect-Accept-Encoding", "Connect-Content-Encoding",
+			"Grpc-Timeout", "X-Grpc-Web", "X-User-Agent", "Traceparent", "Tracestate",
+		}, forwardedHeaders...),
+		ExposedHeaders: []string{
+			"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin",
+			idempotency.HeaderReplayed, interceptor.HeaderRequestID,
+		},
+		MaxAge: int((2 * time.Hour).Seconds()),
+	}).Handler(h)
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return nil
+	default:
+		if s.cfg.TLS.Enabled {
+			tlsCfg, err := tlsconfig.Server(s.cfg.TLS)
+			if err != nil {
+				return err
+			}
+			s.srv.TLSConfig = tlsCfg
+			if err := s.srv.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+				return err
+			}
+			return nil
+		}
+		if err := s.srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	}
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	defer s.conn.Close()
+	return s.srv.Shutdown(ctx)
+}
+
+// notesHandler implements the Connect service by calling the gRPC one.
+type notesHandler struct {
+	cl pb.NotesClient
+}
+
+// outgoing passes the forwarded headers, the IP address and the trace context
+// of the caller to the gRPC server.
+func outgoing(ctx context.Context, h http.Header, p connect.Peer) context.Context {
+	md := metadata.MD{}
+	for _, key := range forwardedHeaders {
+		if v := h.Values(key); len(v) > 0 {
+			md.Set(strings.ToLower(key), v...)
+		}
+	}
+	if host, _, err := net.SplitHostPort(p.Addr); err == nil {
+		md.Set(interceptor.ClientIPMD, host)
+	}
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(h))
+	return tracing.InjectOutgoing(metadata.NewOutgoingContext(ctx, md))
+}
+
+// unary calls a unary gRPC method with the message of req and returns its
+// response and header metadata.
+func unary[Req, Res any](ctx context.Context, req *connect.Request[Req],
+	call func(context.Context, *Req, ...grpc.CallOption) (*Res, error),
+) (*connect.Response[Res], error) {
+	var header metadata.MD
+	res, err := call(outgoing(ctx, req.Header(), req.Peer()), req.Msg, grpc.Header(&header))
+	if err != nil {
+		return nil, connectError(err)
+	}
+	out := connect.NewResponse(res)
+	for _, key := range []string{idempotency.HeaderReplayed, interceptor.HeaderRequestID} {
+		if v := header.Get(key); len(v) > 0 {
+			out.Header().Set(key, v[0])
+		}
+	}
+	return out, nil
+}
+
+// forward sends the messages of a gRPC server stream until it ends.
+func forward[Res any](recv func() (*Res, error), stream *connect.ServerStream[Res]) error {
+	for {
+		msg, err := recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return connectError(err)
+		}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// connectError keeps the code and message of a gRPC status, the codes of both protocols match.
+func connectError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return connect.NewError(connect.CodeUnknown, err)
+	}
+	return connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+}
+
+func (h *notesHandler) GetNotes(ctx context.Context, req *connect.Request[pb.GetNotesRequest],
+) (*connect.Response[pb.GetNotesResponse], error) {
+	return unary(ctx, req, h.cl.GetNotes)
+}
+
+func (h *notesHandler) GetNote(ctx context.Context, req *connect.Request[pb.GetNoteRequest],
+) (*connect.Response[pb.GetNoteResponse], error) {
+	return unary(ctx, req, h.cl.GetNote)
+}
+
+func (h *notesHandler) CreateNote(ctx context.Context, req *connect.Request[pb.CreateNoteRequest],
+) (*connect.Response[pb.CreateNoteResponse], error) {
+	return unary(ctx, req, h.cl.CreateNote)
+}
+
+func (h *notesHandler) DeleteNote(ctx context.Context, req *connect.Request[pb.DeleteNoteRequest],
+) (*connect.Response[pb.DeleteNoteResponse], error) {
+	return unary(ctx, req, h.cl.DeleteNote)
+}
+
+func (h *notesHandler) UpdateNote(ctx context.Context, req *connect.Request[pb.UpdateNoteRequest],
+) (*connect.Response[pb.UpdateNoteResponse], error) {
+	return unary(ctx, req, h.cl.UpdateNote)
+}
+
+func (h *notesHandler) ListTags(ctx context.Context, req *connect.Request[pb.ListTagsRequest],
+) (*connect.Response[pb.ListTagsResponse], error) {
+	return unary(ctx, req, h.cl.ListTags)
+}
+
+func (h *notesHandler) SearchNotes(ctx context.Context, req *connect.Request[pb.SearchNotesRequest],
+) (*connect.Response[pb.SearchNotesResponse], error) {
+	return unary(ctx, req, h.cl.SearchNotes)
+}
+
+func (h *notesHandler) BatchCreate(ctx context.Context, req *connect.Request[pb.BatchCreateRequest],
+) (*connect.Response[pb.BatchCreateResponse], error) {
+	return unary(ctx, req, h.cl.BatchCreate)
+}
+
+func (h *notesHandler) BatchUpdate(ctx context.Context, req *connect.Request[pb.BatchUpdateRequest],
+) (*connect.Response[pb.BatchUpdateResponse], error) {
+	return unary(ctx, req, h.cl.BatchUpdate)
+}
+
+func (h *notesHandler) BatchDelete(ctx context.Context, req *connect.Request[pb.BatchDeleteRequest],
+) (*connect.Response[pb.BatchDeleteResponse], error) {
+	return unary(ctx, req, h.cl.BatchDelete)
+}
+
+func (h *notesHandler) RefreshNote(ctx context.Context, req *connect.Request[pb.RefreshNoteRequest],
+) (*connect.Response[pb.RefreshNoteResponse], error) {
+	return unary(ctx, req, h.cl.RefreshNote)
+}
+
+func (h *notesHandler) SnoozeNote(ctx context.Context, req *connect.Request[pb.SnoozeNoteRequest],
+) (*connect.Response[pb.SnoozeNoteResponse], error) {
+	return unary(ctx, req, h.cl.SnoozeNote)
+}
+
+func (h *notesHandler) AcknowledgeNote(ctx context.Context, req *connect.Request[pb.AcknowledgeNoteRequest],
+) (*connect.Response[pb.AcknowledgeNoteResponse], error) {
+	return unary(ctx, req, h.cl.AcknowledgeNote)
+}
+
+func (h *notesHandler) ListNotes(ctx context.Context, req *connect.Request[pb.ListNotesRequest],
+	stream *connect.ServerStream[pb.Note],
+) error {
+	st, err := h.cl.ListNotes(outgoing(ctx, req.Header(), req.Peer()), req.Msg)
+	if err != nil {
+		return connectError(err)
+	}
+	return forward(st.Recv, stream)
+}
+
+func (h *notesHandler) WatchNotes(ctx context.Context, req *connect.Request[pb.WatchNotesRequest],
+	stream *connect.ServerStream[pb.NoteEvent],
+) error {
+	st, err := h.cl.WatchNotes(outgoing(ctx, req.Header(), req.Peer()), req.Msg)
+	if err != nil {
+		return connectError(err)
+	}
+	return forward(st.Recv, stream)
+}
@@ -0,0 +1,113 @@
+package connectserver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"notes/internal/notes/app"
+	"notes/internal/notes/server/grpcserver"
+	"notes/internal/notes/server/grpcserver/pb"
+	"notes/internal/notes/server/grpcserver/pb/pbconnect"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/config"
+	"notes/internal/pkg/logger"
+	"notes/internal/pkg/models"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var nilError error
+
+func TestConnect(t *testing.T) {
+	mockStr := new(storage.MockStorage)
+	logg, err := logger.New(logger.EnvLocal)
+	require.NoError(t, err)
+
+	server, err := grpcserver.New(app.NewApp(mockStr), logg, config.GRPCServer{})
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	pb.RegisterNotesServer(s, server)
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	defer s.Stop()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	path, h := NewHandler(conn, []string{"https://notes.example"})
+	mux := http.NewServeMux()
+	mux.Handle(path, h)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	t.Run("Test Get Note", func(t *testing.T) {
+		mockStr.On("GetNote", ctx, uint64(5)).Return(models.Note{ID: 5, Title: "milk"}, nilError)
+		mockStr.On("GetNote", ctx, uint64(6)).Return(models.Note{}, storage.ErrNotFound)
+
+		for name, opts := range map[string][]connect.ClientOption{
+			"connect":  nil,
+			"grpc-web": {connect.WithGRPCWeb()},
+		} {
+			cl := pbconnect.NewNotesClient(srv.Client(), srv.URL, opts...)
+			res, err := cl.GetNote(ctx, connect.NewRequest(&pb.GetNoteRequest{ID: 5}))
+			require.NoError(t, err, name)
+			assert.Equal(t, "milk", res.Msg.GetNote().GetTitle(), name)
+
+			_, err = cl.GetNote(ctx, connect.NewRequest(&pb.GetNoteRequest{ID: 6}))
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err), name)
+		}
+	})
+
+	t.Run("Test List Notes Streams", func(t *testing.T) {
+		mockStr.On("GetNotes", ctx, models.NotesFilter{PageSize: app.MaxPageSize}).
+			Return(models.NotesPage{Notes: []models.Note{{ID: 1}, {ID: 2}}}, nilError)
+
+		cl := pbconnect.NewNotesClient(srv.Client(), srv.URL, connect.WithGRPCWeb())
+		st, err := cl.ListNotes(ctx, connect.NewRequest(&pb.ListNotesRequest{}))
+		require.NoError(t, err)
+		var ids []uint64
+		for st.Receive() {
+			ids = append(ids, st.Msg().GetID())
+		}
+		require.NoError(t, st.Err())
+		assert.Equal(t, []uint64{1, 2}, ids)
+	})
+
+	t.Run("Test CORS Preflight", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, pbconnect.NotesGetNoteProcedure, nil)
+		req.Header.Set("Origin", "https://notes.example")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "authorization,content-type,x-grpc-web")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "https://notes.example", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.True(t, strings.Contains(strings.ToLower(w.Header().Get("Access-Control-Allow-Headers")), "authorization"))
+
+		req.Header.Set("Origin", "https://evil.example")
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	mockStr.AssertExpectations(t)
+}
@@ -0,0 +1,142 @@
+// Package gateway serves the REST API that grpc-gateway generates from the HTTP
+// annotations of notes.proto. Requests are proxied to the gRPC server, so both
+// APIs share its handlers, interceptors and error codes.
+package gateway
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/textproto"
+	"notes/api"
+	"notes/internal/notes/idempotency"
+	"notes/internal/notes/server/grpcserver/interceptor"
+	"notes/internal/notes/server/grpcserver/pb"
+	"notes/internal/pkg/auth"
+	"notes/internal/pkg/config"
+	"notes/internal/pkg/tlsconfig"
+	"notes/internal/pkg/tracing"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// OpenAPIPath serves the OpenAPI document of the gateway routes.
+const OpenAPIPath = "/openapi.json"
+
+type Server struct {
+	cfg  config.Gateway
+	conn *grpc.ClientConn
+	srv  *http.Server
+}
+
+// New returns a gateway to the gRPC server at grpcCfg, dialed with cfg.ClientTLS.
+// The connection is made lazily, the gRPC server doesn't have to be up yet.
+func New(ctx context.Context, cfg config.Gateway, grpcCfg config.GRPCServer) (*Server, error) {
+	creds, err := tlsconfig.ProxyCredentials(cfg.ClientTLS, grpcCfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.DialContext(ctx, grpcCfg.Host+grpcCfg.Port,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithUnaryInterceptor(tracing.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(tracing.StreamClientInterceptor()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := NewHandler(ctx, conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &Server{
+		cfg:  cfg,
+		conn: conn,
+		srv: &http.Server{
+			Addr:              cfg.Host + cfg.Port,
+			Handler:           h,
+			ReadHeaderTimeout: time.Second * 5,
+		},
+	}, nil
+}
+
+// NewHandler returns the REST routes of notes.proto proxied to conn, and the
+// OpenAPI document on OpenAPIPath.
+func NewHandler(ctx context.Context, conn *grpc.ClientConn) (http.Handler, error) {
+	gw := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(headerMatcher), runtime.WithMetadata(clientIP))
+	if err := pb.RegisterNotesHandler(ctx, gw, conn); err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", withTraceContext(gw))
+	mux.HandleFunc(OpenAPIPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(api.OpenAPI)
+	})
+	return mux, nil
+}
+
+// headerMatcher forwards the credentials, idempotency key and request ID headers as the
+// metadata keys the gRPC interceptors read, the default matcher prefixes them.
+func headerMatcher(key string) (string, bool) {
+	switch textproto.CanonicalMIMEHeaderKey(key) {
+	case auth.HeaderAuthorization, auth.HeaderAPIKey, idempotency.HeaderKey, interceptor.HeaderRequestID:
+		return strings.ToLower(key), true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// clientIP passes the IP address of the REST client, which keys its rate limits
+// when it isn't authenticated.
+func clientIP(_ context.Context, r *http.Request) metadata.MD {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return nil
+	}
+	return metadata.Pairs(interceptor.ClientIPMD, host)
+}
+
+// withTraceContext continues the trace of the REST client in the proxied call.
+func withTraceContext(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return nil
+	default:
+		if s.cfg.TLS.Enabled {
+			tlsCfg, err := tlsconfig.Server(s.cfg.TLS)
+			if err != nil {
+				return err
+			}
+			s.srv.TLSConfig = tlsCfg
+			if err := s.srv.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+				return err
+			}
+			return nil
+		}
+		if err := s.srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	}
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	defer s.conn.Close()
+	return s.srv.Shutdown(ctx)
+}
@@ -0,0 +1,157 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"notes/internal/notes/app"
+	"notes/internal/notes/ratelimit"
+	notesserver "notes/internal/notes/server"
+	"notes/internal/notes/server/grpcserver"
+	"notes/internal/notes/server/grpcserver/pb"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/config"
+	"notes/internal/pkg/logger"
+	"notes/internal/pkg/models"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var nilError error
+
+func TestGateway(t *testing.T) {
+	mockStr := new(storage.MockStorage)
+	logg, err := logger.New(logger.EnvLocal)
+	require.NoError(t, err)
+
+	server, err := grpcserver.New(app.NewApp(mockStr), logg, config.GRPCServer{})
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	pb.RegisterNotesServer(s, server)
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	defer s.Stop()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	h, err := NewHandler(context.Background(), conn)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Test Get Note", func(t *testing.T) {
+		mockStr.On("GetNote", ctx, uint64(5)).Return(models.Note{ID: 5, Title: "milk", Version: 2}, nilError)
+		mockStr.On("GetNote", ctx, uint64(6)).Return(models.Note{}, storage.ErrNotFound)
+
+		w := serve(http.MethodGet, "/v1/notes/5", "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var res struct {
+			Note struct {
+				ID    string `json:"ID"`
+				Title string `json:"title"`
+			} `json:"note"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, "5", res.Note.ID)
+		assert.Equal(t, "milk", res.Note.Title)
+
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/v1/notes/6", "").Code)
+	})
+
+	t.Run("Test Update Note Sets Body Fields", func(t *testing.T) {
+		mockStr.On("UpdateNote", ctx, models.Note{ID: 5, Title: "bread"},
+			[]models.NoteField{models.FieldTitle}).Return(nilError)
+
+		w := serve(http.MethodPatch, "/v1/notes/5", `{"title":"bread"}`)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("Test OpenAPI", func(t *testing.T) {
+		w := serve(http.MethodGet, OpenAPIPath, "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"/v1/notes/{ID}"`)
+	})
+
+	mockStr.AssertExpectations(t)
+}
+
+func TestGatewayRateLimit(t *testing.T) {
+	mockStr := new(storage.MockStorage)
+	logg, err := logger.New(logger.EnvLocal)
+	require.NoError(t, err)
+
+	limiter := ratelimit.New(config.RateLimit{
+		Routes: map[string]config.Limit{pb.Notes_GetNote_FullMethodName: {Rate: 0.001, Burst: 1}},
+	})
+	server, err := grpcserver.New(app.NewApp(mockStr), logg,
+		config.GRPCServer{TrustedProxies: []string{"127.0.0.1"}}, notesserver.WithRateLimiter(limiter))
+	require.NoError(t, err)
+
+	// The gateway reaches the gRPC server over loopback, as it does in the notes service.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = server.Serve(ctx, lis)
+	}()
+	defer server.Shutdown(ctx)
+
+	conn, err := grpc.DialContext(ctx, lis.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	h, err := NewHandler(ctx, conn)
+	require.NoError(t, err)
+
+	mockStr.On("GetNote", context.Background(), uint64(5)).Return(models.Note{ID: 5}, nilError)
+	get := func(remoteAddr string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/notes/5", nil)
+		req.RemoteAddr = remoteAddr
+		h.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Every client has its own bucket, though all calls come from the gateway.
+	assert.Equal(t, http.StatusOK, get("192.0.2.1:1234"))
+	assert.Equal(t, http.StatusTooManyRequests, get("192.0.2.1:1235"))
+	assert.Equal(t, http.StatusOK, get("192.0.2.2:1234"))
+}
+
+func TestHeaderMatcher(t *testing.T) {
+	for header, want := range map[string]string{
+		"Authorization":   "authorization",
+		"x-api-key":       "x-api-key",
+		"Idempotency-Key": "idempotency-key",
+		"X-Request-ID":    "x-request-id",
+	} {
+		key, ok := headerMatcher(header)
+		assert.True(t, ok, header)
+		assert.Equal(t, want, key, header)
+	}
+}
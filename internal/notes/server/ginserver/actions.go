@@ -0,0 +1,97 @@
+package ginserver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"notes/internal/notes/app"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/models"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var ErrBadSnooze = errors.New("snooze must have exactly one of until and for")
+
+// NoteAction serves the custom methods on a note, POST /v1/notes/{id}:<action>.
+// Every action replies with the changed note.
+func (s *Server) NoteAction(c *gin.Context) {
+	idS, action, _ := strings.Cut(c.Param("id"), ":")
+	id, ok := noteID(c, idS)
+	if !ok {
+		return
+	}
+	switch action {
+	case "refresh":
+		s.refreshNote(c, id)
+	case "snooze":
+		s.snoozeNote(c, id)
+	case "acknowledge":
+		note, err := s.a.AcknowledgeNote(c.Request.Context(), id)
+		s.actionResult(c, note, err)
+	default:
+		c.AbortWithStatus(http.StatusNotFound)
+	}
+}
+
+// refreshNote replies with no content when the note was deleted, see app.RefreshNote.
+func (s *Server) refreshNote(c *gin.Context, id uint64) {
+	note, deleted, err := s.a.RefreshNote(c.Request.Context(), id)
+	if err == nil && deleted {
+		c.Status(http.StatusNoContent)
+		return
+	}
+	s.actionResult(c, note, err)
+}
+
+// snoozeRequest is the body of :snooze, a time or a duration such as "1h30m".
+type snoozeRequest struct {
+	Until time.Time `json:"until"`
+	For   string    `json:"for"`
+}
+
+func (s *Server) snoozeNote(c *gin.Context, id uint64) {
+	var r snoozeRequest
+	if err := c.BindJSON(&r); err != nil {
+		return
+	}
+
+	until := r.Until
+	switch {
+	case r.For != "" && until.IsZero():
+		d, err := time.ParseDuration(r.For)
+		if err != nil {
+			c.AbortWithError(http.StatusBadRequest, fmt.Errorf("%w: %s", ErrBadSnooze, err))
+			return
+		}
+		until = time.Now().Add(d)
+	case r.For != "" || until.IsZero():
+		c.AbortWithError(http.StatusBadRequest, ErrBadSnooze)
+		return
+	}
+
+	note, err := s.a.SnoozeNote(c.Request.Context(), id, until)
+	s.actionResult(c, note, err)
+}
+
+func (s *Server) actionResult(c *gin.Context, note models.Note, err error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		case errors.Is(err, app.ErrSnoozeInPast):
+			c.AbortWithError(http.StatusBadRequest, err)
+			return
+		case errors.Is(err, app.ErrQuotaExceeded):
+			c.AbortWithError(http.StatusTooManyRequests, err)
+			return
+		}
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	c.Header("ETag", etag(note.Version))
+	c.JSON(http.StatusOK, note)
+}
@@ -0,0 +1,138 @@
+package ginserver
+
+import (
+	"errors"
+	"net/http"
+	"notes/internal/notes/app"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+var ErrBadBatch = errors.New("batch must have exactly one of create, update and delete")
+
+// batchRequest is the body of POST /notes:batch.
+type batchRequest struct {
+	// Mode is "atomic", the default, or "partial".
+	Mode   models.BatchMode    `json:"mode"`
+	Create []models.Note       `json:"create"`
+	Update []models.NoteUpdate `json:"update"`
+	Delete []models.NoteRef    `json:"delete"`
+	// KeepSchedule imports the notes to create with their delay and a notify
+	// date in the future, the server schedules them otherwise.
+	KeepSchedule bool `json:"keepSchedule"`
+}
+
+type batchItem struct {
+	ID      uint64 `json:"id,omitempty"`
+	Version uint64 `json:"version,omitempty"`
+	Status  int    `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+type batchResponse struct {
+	Results []batchItem `json:"results"`
+}
+
+// batchFailure is returned when an atomic batch is rolled back by the item at Index.
+type batchFailure struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+// NotesAction serves the custom methods on the notes collection, POST /notes:<action>.
+// Gin reads the colon as the start of a parameter, so the action includes it.
+func (s *Server) NotesAction(c *gin.Context) {
+	switch c.Param("action") {
+	case ":batch":
+		s.BatchNotes(c)
+	default:
+		c.AbortWithStatus(http.StatusNotFound)
+	}
+}
+
+// BatchNotes serves POST /notes:batch. The body creates, updates or deletes many
+// notes in one transaction:
+//
+//	{"mode": "partial", "update": [{"note": {"id": 1, "title": "new", "version": 3}, "fields": ["title"]}]}
+//
+// Notes to create are scheduled by the server, unless "keepSchedule" imports them
+// with their delay and notify date. Atomic batches fail as a whole with the
+// status of the first failing item and its index. Partial batches reply 200 with
+// the status of every item.
+func (s *Server) BatchNotes(c *gin.Context) {
+	var req batchRequest
+	if err := c.BindJSON(&req); err != nil {
+		return
+	}
+
+	ctx := c.Request.Context()
+	var (
+		results []models.BatchResult
+		okCode  = http.StatusOK
+		err     error
+	)
+	switch {
+	case len(req.Create) > 0 && len(req.Update) == 0 && len(req.Delete) == 0:
+		create := s.a.CreateNotes
+		if req.KeepSchedule {
+			create = s.a.ImportNotes
+		}
+		results, err = create(ctx, req.Create, req.Mode)
+		okCode = http.StatusCreated
+	case len(req.Update) > 0 && len(req.Create) == 0 && len(req.Delete) == 0:
+		results, err = s.a.UpdateNotes(ctx, req.Update, req.Mode)
+	case len(req.Delete) > 0 && len(req.Create) == 0 && len(req.Update) == 0:
+		results, err = s.a.DeleteNotes(ctx, req.Delete, req.Mode)
+	default:
+		c.AbortWithError(http.StatusBadRequest, ErrBadBatch)
+		return
+	}
+
+	var batchErr *storage.BatchError
+	switch {
+	case errors.As(err, &batchErr):
+		c.Error(err)
+		c.AbortWithStatusJSON(batchStatus(batchErr.Err), batchFailure{
+			Index: batchErr.Index,
+			Error: batchErr.Err.Error(),
+		})
+		return
+	case err != nil:
+		c.AbortWithError(batchStatus(err), err)
+		return
+	}
+
+	res := batchResponse{Results: make([]batchItem, len(results))}
+	for i, r := range results {
+		item := batchItem{ID: r.ID, Version: r.Version, Status: okCode}
+		if r.Err != nil {
+			item.Status, item.Error = batchStatus(r.Err), r.Err.Error()
+		}
+		res.Results[i] = item
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func batchStatus(err error) int {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, storage.ErrVersionConflict):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, storage.ErrInvalidField),
+		errors.Is(err, storage.ErrFieldUnspecified),
+		errors.Is(err, storage.ErrNotEnoughArguments),
+		errors.Is(err, app.ErrEmptyBatch),
+		errors.Is(err, app.ErrInvalidBatchMode):
+		return http.StatusBadRequest
+	case errors.Is(err, app.ErrBatchTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, app.ErrQuotaExceeded):
+		return http.StatusTooManyRequests
+	case errors.Is(err, storage.ErrUnknownOwner):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
@@ -3,31 +3,45 @@ package ginserver
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"notes/internal/notes/app"
 	"notes/internal/notes/server"
 	"notes/internal/notes/server/ginserver/middlewares"
 	"notes/internal/notes/storage"
 	"notes/internal/pkg/config"
 	"notes/internal/pkg/logger"
 	"notes/internal/pkg/models"
+	"notes/internal/pkg/tlsconfig"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+var (
+	ErrBadQuery  = errors.New("bad query parameter")
+	ErrNoIfMatch = errors.New("If-Match header is required")
+)
+
+// HeaderNextPageToken carries the token of the next page of GET /notes.
+const HeaderNextPageToken = "X-Next-Page-Token"
+
 type Server struct {
 	a    server.App
 	cfg  config.Server
+	opts server.Options
 	srv  *http.Server
 	logg logger.Logger
 	e    *gin.Engine
 }
 
-func New(a server.App, cfg config.Server, logg logger.Logger) *Server {
+func New(a server.App, cfg config.Server, logg logger.Logger, opts ...server.Option) *Server {
 	s := &Server{
-		a:   a,
-		cfg: cfg,
+		a:    a,
+		cfg:  cfg,
+		opts: server.NewOptions(opts...),
 		srv: &http.Server{
 			Addr:              cfg.Host + cfg.Port,
 			ReadHeaderTimeout: time.Duration(cfg.ShutDownTimeout) * time.Second,
@@ -49,6 +63,17 @@ func (s *Server) Start(ctx context.Context) error {
 	case <-ctx.Done():
 		return nil
 	default:
+		if s.cfg.TLS.Enabled {
+			tlsCfg, err := tlsconfig.Server(s.cfg.TLS)
+			if err != nil {
+				return err
+			}
+			s.srv.TLSConfig = tlsCfg
+			if err := s.srv.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+				return err
+			}
+			return nil
+		}
 		if err := s.srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			return err
 		}
@@ -70,53 +95,172 @@ func (s *Server) Shutdown(ctx context.Context) error {
 
 func (s *Server) Register() {
 	e := gin.Default()
+	// The client IP keys the rate limits of unauthenticated callers, it is only
+	// taken from X-Forwarded-For when set by a trusted proxy.
+	if err := e.SetTrustedProxies(s.cfg.TrustedProxies); err != nil {
+		s.logg.Errorf("bad trusted proxies, none are trusted: %s", err)
+		_ = e.SetTrustedProxies(nil)
+	}
 
 	e.Use(gin.Recovery())
+
+	e.GET("/healthz", gin.WrapH(s.opts.Health.LiveHandler()))
+	e.GET("/readyz", gin.WrapH(s.opts.Health.ReadyHandler()))
+	if s.opts.Connect != nil {
+		// Registered before the middlewares, the proxied gRPC server traces,
+		// measures, logs, authenticates and rate limits these calls itself.
+		e.Any(s.opts.ConnectPath+"*method", gin.WrapH(s.opts.Connect))
+	}
+
+	e.Use(middlewares.TracingMiddleware())
+	e.Use(middlewares.MetricsMiddleware())
 	e.Use(middlewares.LoggingMiddleware(s.logg))
+	if s.opts.Auth != nil {
+		e.Use(middlewares.AuthMiddleware(s.opts.Auth))
+	}
+	if s.opts.RateLimit != nil {
+		e.Use(middlewares.RateLimitMiddleware(s.opts.RateLimit))
+	}
+
+	var idempotent []gin.HandlerFunc
+	if s.opts.Idempotency != nil {
+		idempotent = append(idempotent, middlewares.IdempotencyMiddleware(s.opts.Idempotency, s.logg))
+	}
+
+	v1 := e.Group("/v1", idempotent...)
+	v1.GET("/notes", s.GetNotes)
+	v1.POST("/notes", s.CreateNote)
+	// Collection methods, such as POST /v1/notes:batch.
+	v1.POST("/notes:action", s.NotesAction)
+	v1.GET("/notes/search", s.SearchNotes)
+	v1.GET("/notes/stream", s.StreamNotes)
+	v1.GET("/notes/:id", s.GetNote)
+	v1.PUT("/notes/:id", s.ReplaceNote)
+	v1.PATCH("/notes/:id", s.UpdateNote)
+	v1.DELETE("/notes/:id", s.DeleteNote)
+	// Note methods, such as POST /v1/notes/1:refresh.
+	v1.POST("/notes/:id", s.NoteAction)
+	v1.GET("/tags", s.ListTags)
 
-	notes := e.Group("/notes")
-	notes.GET("/", s.GetNotes)
-	notes.GET("/:id", s.GetNote)
-	notes.PUT("/", s.CreateNote)
-	notes.DELETE("/", s.DeleteNote)
-	notes.PATCH("/", s.UpdateNote)
+	s.registerLegacy(e, idempotent)
 	s.e = e
 	s.srv.Handler = e
 }
 
 func (s *Server) GetNotes(c *gin.Context) {
-	ctx := context.Background()
-	dur, ok := c.GetQuery("interval")
-	var interval time.Duration
-	if ok {
-		var err error
-		interval, err = time.ParseDuration(dur)
+	ctx := c.Request.Context()
+	filter, err := notesFilter(c)
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	page, err := s.a.GetNotes(ctx, filter)
+	if err != nil {
+		if errors.Is(err, storage.ErrInvalidPageToken) || errors.Is(err, storage.ErrInvalidSort) {
+			c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
+		if err := c.AbortWithError(http.StatusInternalServerError, err); err.Err != nil {
+			return
+		}
+		return
+	}
+	if page.NextPageToken != "" {
+		c.Header(HeaderNextPageToken, page.NextPageToken)
+	}
+	c.JSON(http.StatusOK, page.Notes)
+}
+
+// notesFilter reads ?interval=5m&tags=a,b&match=all&sort=date_notify&order=desc&page_size=20&page_token=...
+// from the query.
+func notesFilter(c *gin.Context) (models.NotesFilter, error) {
+	var filter models.NotesFilter
+	if dur, ok := c.GetQuery("interval"); ok {
+		interval, err := time.ParseDuration(dur)
 		if err != nil {
-			if err := c.AbortWithError(http.StatusBadRequest, err); err != nil {
-				return
-			}
+			return filter, err
+		}
+		filter.Interval = interval
+	}
+
+	for _, t := range c.QueryArray("tags") {
+		filter.Tags = append(filter.Tags, strings.Split(t, ",")...)
+	}
+
+	switch match := c.Query("match"); match {
+	case "", "any":
+	case "all":
+		filter.MatchAllTags = true
+	default:
+		return filter, fmt.Errorf("%w: match=%s", ErrBadQuery, match)
+	}
+
+	filter.SortBy = c.Query("sort")
+	switch order := c.Query("order"); order {
+	case "", "asc":
+	case "desc":
+		filter.Descending = true
+	default:
+		return filter, fmt.Errorf("%w: order=%s", ErrBadQuery, order)
+	}
+
+	if size, ok := c.GetQuery("page_size"); ok {
+		n, err := strconv.Atoi(size)
+		if err != nil {
+			return filter, fmt.Errorf("%w: page_size=%s", ErrBadQuery, size)
+		}
+		filter.PageSize = n
+	}
+	filter.PageToken = c.Query("page_token")
+	return filter, nil
+}
+
+// SearchNotes serves GET /notes/search?q=...&limit=20&offset=0.
+func (s *Server) SearchNotes(c *gin.Context) {
+	q := models.SearchQuery{Query: c.Query("q")}
+
+	var err error
+	if v, ok := c.GetQuery("limit"); ok {
+		if q.Limit, err = strconv.Atoi(v); err != nil {
+			c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
+	}
+	if v, ok := c.GetQuery("offset"); ok {
+		if q.Offset, err = strconv.Atoi(v); err != nil {
+			c.AbortWithError(http.StatusBadRequest, err)
 			return
 		}
 	}
 
-	notes, err := s.a.GetNotes(ctx, interval)
+	page, err := s.a.Search(c.Request.Context(), q)
 	if err != nil {
-		if err := c.AbortWithError(http.StatusInternalServerError, err); err.Err != nil {
+		if errors.Is(err, app.ErrEmptyQuery) {
+			c.AbortWithError(http.StatusBadRequest, err)
 			return
 		}
+		c.AbortWithError(http.StatusInternalServerError, err)
 		return
 	}
-	c.JSON(http.StatusOK, notes)
+	c.JSON(http.StatusOK, page)
 }
 
-func (s *Server) GetNote(c *gin.Context) {
-	ctx := context.Background()
-	noteID := c.Param("id")
-	id, err := strconv.ParseUint(noteID, 10, 64)
+func (s *Server) ListTags(c *gin.Context) {
+	tags, err := s.a.ListTags(c.Request.Context())
 	if err != nil {
 		c.AbortWithError(http.StatusInternalServerError, err)
 		return
 	}
+	c.JSON(http.StatusOK, tags)
+}
+
+func (s *Server) GetNote(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, ok := noteID(c, c.Param("id"))
+	if !ok {
+		return
+	}
 	note, err := s.a.GetNote(ctx, id)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
@@ -128,42 +272,62 @@ func (s *Server) GetNote(c *gin.Context) {
 	}
 
 	s.logg.Debugf("get note debug: note %v\n", note)
+	c.Header("ETag", etag(note.Version))
 	c.JSON(200, note)
 }
 
+// CreateNote serves POST /v1/notes and replies with the created note.
 func (s *Server) CreateNote(c *gin.Context) {
 	var n models.Note
 	if err := c.BindJSON(&n); err != nil {
 		s.logg.Debugf("create note debug: error: %v\n", err)
-		c.AbortWithError(http.StatusBadRequest, err)
 		return
 	}
 
 	s.logg.Debugf("create note debug: note: %v\n", n)
-	ctx := context.Background()
-	if err := s.a.CreateNote(ctx, n); err != nil {
+	n, err := s.a.CreateNote(c.Request.Context(), n)
+	if err != nil {
 		s.logg.Debugf("error: %v\n", err)
-		c.AbortWithError(http.StatusInternalServerError, err)
+		switch {
+		case errors.Is(err, app.ErrQuotaExceeded):
+			c.AbortWithError(http.StatusTooManyRequests, err)
+		case errors.Is(err, storage.ErrUnknownOwner):
+			c.AbortWithError(http.StatusForbidden, err)
+		default:
+			c.AbortWithError(http.StatusInternalServerError, err)
+		}
 		return
 	}
-	c.Header("Content-Type", "application/json")
-	c.Status(http.StatusCreated)
+	c.Header("Location", "/v1/notes/"+strconv.FormatUint(n.ID, 10))
+	c.Header("ETag", etag(n.Version))
+	c.JSON(http.StatusCreated, n)
 }
 
+// DeleteNote serves DELETE /v1/notes/{id}.
 func (s *Server) DeleteNote(c *gin.Context) {
-	idS, ok := c.GetQuery("id")
+	id, ok := noteID(c, c.Param("id"))
 	if !ok {
-		c.AbortWithStatus(http.StatusNotAcceptable)
 		return
 	}
-	id, err := strconv.ParseUint(idS, 10, 64)
-	if err != nil {
-		c.AbortWithError(http.StatusInternalServerError, err)
+	s.deleteNote(c, id)
+}
+
+func (s *Server) deleteNote(c *gin.Context, id uint64) {
+	version, ok := ifMatch(c)
+	if !ok {
 		return
 	}
 
-	ctx := context.Background()
-	if err := s.a.DeleteNote(ctx, id); err != nil {
+	ctx := c.Request.Context()
+	if err := s.a.DeleteNote(ctx, id, version); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		case errors.Is(err, storage.ErrVersionConflict):
+			c.AbortWithError(http.StatusPreconditionFailed, err)
+			return
+		}
 		c.AbortWithError(http.StatusInternalServerError, err)
 		return
 	}
@@ -171,46 +335,132 @@ func (s *Server) DeleteNote(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// UpdateNote serves PATCH /v1/notes/{id}. A JSON note body updates its non-zero
+// fields, a merge patch body (MergePatchContentType) updates the fields it contains.
 func (s *Server) UpdateNote(c *gin.Context) {
+	id, ok := noteID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+	n, fields, ok := patchBody(c)
+	if !ok || !sameID(c, id, n.ID) {
+		return
+	}
+	n.ID = id
+	s.updateNote(c, n, fields)
+}
+
+// replacedFields are the fields a PUT sets, the others can't be changed by clients.
+var replacedFields = []models.NoteField{
+	models.FieldTitle, models.FieldDescription, models.FieldDateNotify, models.FieldDelay, models.FieldTags,
+}
+
+// ReplaceNote serves PUT /v1/notes/{id}. Fields missing from the body are cleared.
+func (s *Server) ReplaceNote(c *gin.Context) {
+	id, ok := noteID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+	var n models.Note
+	if err := c.BindJSON(&n); err != nil || !sameID(c, id, n.ID) {
+		return
+	}
+	n.ID = id
+	if n.Tags == nil {
+		n.Tags = []string{}
+	}
+	s.updateNote(c, n, replacedFields)
+}
+
+// patchBody reads a JSON note or a merge patch, see UpdateNote.
+func patchBody(c *gin.Context) (models.Note, []models.NoteField, bool) {
+	if c.ContentType() == MergePatchContentType {
+		n, fields, err := mergePatch(c.Request.Body)
+		if err != nil {
+			c.AbortWithError(http.StatusBadRequest, err)
+			return n, nil, false
+		}
+		return n, fields, true
+	}
+
 	var n models.Note
 	if err := c.BindJSON(&n); err != nil {
-		c.AbortWithError(http.StatusBadRequest, err)
+		return n, nil, false
+	}
+	return n, nil, true
+}
+
+func (s *Server) updateNote(c *gin.Context, n models.Note, fields []models.NoteField) {
+	version, ok := ifMatch(c)
+	if !ok {
 		return
 	}
+	n.Version = version
 	s.logg.Debugf("update note debug: note: %v\n", n)
-	s.logg.Debugf("refreshed header: %s\n", c.GetHeader("Refreshed"))
-	ctx := context.Background()
-	switch {
-	case c.GetHeader("Refreshed") != "":
-		if c.GetHeader("Refreshed") != "true" {
-			c.AbortWithStatus(http.StatusBadRequest)
-			return
-		}
-		s.logg.Debugf("refresh note debug: note: %v\n", n)
 
-		if err := s.a.RefreshNote(ctx, n); err != nil {
-			if errors.Is(err, storage.ErrNotFound) {
-				c.AbortWithStatus(http.StatusNotFound)
-				return
-			}
-			c.AbortWithError(http.StatusInternalServerError, err)
+	if err := s.a.UpdateNote(c.Request.Context(), n, fields); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			c.AbortWithStatus(http.StatusNotFound)
 			return
-		}
-	default:
-		if err := s.a.UpdateNote(ctx, n); err != nil {
-			switch {
-			case errors.Is(err, storage.ErrNotFound):
-				c.AbortWithStatus(http.StatusNotFound)
-				return
-			case errors.Is(err, storage.ErrNotEnoughArguments):
-				c.AbortWithError(http.StatusBadRequest, err)
-				return
-			}
-			c.AbortWithError(http.StatusInternalServerError, err)
+		case errors.Is(err, storage.ErrNotEnoughArguments), errors.Is(err, storage.ErrInvalidField):
+			c.AbortWithError(http.StatusBadRequest, err)
+			return
+		case errors.Is(err, storage.ErrVersionConflict):
+			c.AbortWithError(http.StatusPreconditionFailed, err)
 			return
 		}
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	if version != 0 {
+		c.Header("ETag", etag(version+1))
 	}
-
 	c.Header("Content-Type", "application/json")
 	c.Status(http.StatusNoContent)
 }
+
+// noteID parses a note id, aborting the request with 400 when it is malformed.
+func noteID(c *gin.Context, s string) (uint64, bool) {
+	id, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || id == 0 {
+		c.AbortWithError(http.StatusBadRequest, fmt.Errorf("bad note id %q", s))
+		return 0, false
+	}
+	return id, true
+}
+
+// sameID rejects bodies that name another note than the path.
+func sameID(c *gin.Context, pathID, bodyID uint64) bool {
+	if bodyID != 0 && bodyID != pathID {
+		c.AbortWithError(http.StatusBadRequest, fmt.Errorf("body is for note %d, not %d", bodyID, pathID))
+		return false
+	}
+	return true
+}
+
+func etag(version uint64) string {
+	return `"` + strconv.FormatUint(version, 10) + `"`
+}
+
+// ifMatch returns the note version required by the If-Match header, 0 for "*".
+// Changes without the header are rejected, so that clients can't overwrite
+// changes they haven't seen. It aborts the request and returns false when the
+// header is missing or malformed.
+func ifMatch(c *gin.Context) (uint64, bool) {
+	h := c.GetHeader("If-Match")
+	switch h {
+	case "":
+		c.AbortWithError(http.StatusPreconditionRequired, ErrNoIfMatch)
+		return 0, false
+	case "*":
+		return 0, true
+	}
+
+	v, err := strconv.ParseUint(strings.Trim(strings.TrimPrefix(h, "W/"), `"`), 10, 64)
+	if err != nil || v == 0 {
+		c.AbortWithError(http.StatusBadRequest, fmt.Errorf("bad If-Match header %q", h))
+		return 0, false
+	}
+	return v, true
+}
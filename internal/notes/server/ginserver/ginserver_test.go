@@ -1,22 +1,32 @@
 package ginserver_test
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"notes/internal/notes/app"
+	"notes/internal/notes/events"
+	"notes/internal/notes/idempotency"
+	"notes/internal/notes/ratelimit"
+	"notes/internal/notes/server"
 	"notes/internal/notes/server/ginserver"
 	"notes/internal/notes/storage"
 	"notes/internal/pkg/config"
 	"notes/internal/pkg/logger"
 	"notes/internal/pkg/models"
+	"strings"
 	"testing"
 	"time"
 
 	"bou.ke/monkey"
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -40,9 +50,8 @@ func TestBasic(t *testing.T) {
 	t.Run("Test Get Notes", func(t *testing.T) {
 		w := httptest.NewRecorder()
 
-		exp := []models.Note{}
-		var td time.Duration
-		mockStr.On("GetNotes", ctx, td).Return(exp, nilError)
+		exp := models.NotesPage{Notes: []models.Note{}}
+		mockStr.On("GetNotes", ctx, models.NotesFilter{PageSize: app.DefaultPageSize}).Return(exp, nilError)
 
 		req, err := http.NewRequestWithContext(ctx, "GET", "/notes/", nil)
 		assert.NoError(t, err)
@@ -55,8 +64,8 @@ func TestBasic(t *testing.T) {
 	t.Run("Test Get Notes With interval", func(t *testing.T) {
 		w := httptest.NewRecorder()
 
-		exp := []models.Note{}
-		mockStr.On("GetNotes", ctx, time.Minute*5).Return(exp, nilError)
+		exp := models.NotesPage{Notes: []models.Note{}}
+		mockStr.On("GetNotes", ctx, models.NotesFilter{Interval: time.Minute * 5, PageSize: app.DefaultPageSize}).Return(exp, nilError)
 
 		req, err := http.NewRequestWithContext(ctx, "GET", "/notes/?interval=5m", nil)
 		assert.NoError(t, err)
@@ -66,6 +75,164 @@ func TestBasic(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
 	})
 
+	t.Run("Test Get Notes With tags", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		exp := []models.Note{{ID: 2, Title: "test", Tags: []string{"home", "work"}}}
+		filter := models.NotesFilter{Tags: []string{"home", "work"}, MatchAllTags: true, PageSize: app.DefaultPageSize}
+		mockStr.On("GetNotes", ctx, filter).Return(models.NotesPage{Notes: exp}, nilError)
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "/notes/?tags=Work,home&match=all", nil)
+		assert.NoError(t, err)
+
+		serv.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+		notes := []models.Note{}
+		err = json.Unmarshal(w.Body.Bytes(), &notes)
+		assert.NoError(t, err)
+		assert.Equal(t, exp, notes)
+
+		w = httptest.NewRecorder()
+		req, err = http.NewRequestWithContext(ctx, "GET", "/notes/?tags=work&match=some", nil)
+		assert.NoError(t, err)
+
+		serv.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("Test Get Notes Paginated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		exp := models.NotesPage{Notes: []models.Note{{ID: 7, Title: "test"}}, NextPageToken: "next"}
+		filter := models.NotesFilter{SortBy: models.SortByDateNotify, Descending: true, PageSize: 1, PageToken: "prev"}
+		mockStr.On("GetNotes", ctx, filter).Return(exp, nilError)
+
+		req, err := http.NewRequestWithContext(ctx, "GET",
+			"/notes/?sort=date_notify&order=desc&page_size=1&page_token=prev", nil)
+		assert.NoError(t, err)
+
+		serv.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Equal(t, "next", w.Result().Header.Get(ginserver.HeaderNextPageToken))
+
+		filter = models.NotesFilter{PageSize: app.DefaultPageSize, PageToken: "bad"}
+		mockStr.On("GetNotes", ctx, filter).Return(models.NotesPage{}, storage.ErrInvalidPageToken)
+
+		for _, url := range []string{"/notes/?page_token=bad", "/notes/?order=up", "/notes/?page_size=x"} {
+			w = httptest.NewRecorder()
+			req, err = http.NewRequestWithContext(ctx, "GET", url, nil)
+			assert.NoError(t, err)
+
+			serv.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Test List Tags", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		exp := []models.TagCount{{Name: "home", Count: 1}, {Name: "work", Count: 3}}
+		mockStr.On("ListTags", ctx).Return(exp, nilError)
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "/tags", nil)
+		assert.NoError(t, err)
+
+		serv.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+		tags := []models.TagCount{}
+		err = json.Unmarshal(w.Body.Bytes(), &tags)
+		assert.NoError(t, err)
+		assert.Equal(t, exp, tags)
+	})
+
+	t.Run("Test Search Notes", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		exp := models.SearchPage{
+			Results:    []models.SearchResult{{Note: models.Note{ID: 3, Title: "buy milk"}, Rank: 0.5, Snippet: "buy <b>milk</b>"}},
+			NextOffset: 1,
+		}
+		mockStr.On("Search", ctx, models.SearchQuery{Query: "milk", Limit: app.DefaultSearchLimit}).Return(exp, nilError)
+
+		req, err := http.NewRequestWithContext(ctx, "GET", "/notes/search?q=milk", nil)
+		assert.NoError(t, err)
+
+		serv.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+		page := models.SearchPage{}
+		err = json.Unmarshal(w.Body.Bytes(), &page)
+		assert.NoError(t, err)
+		assert.Equal(t, exp, page)
+
+		for _, url := range []string{"/notes/search?q=+", "/notes/search?q=milk&limit=x"} {
+			w = httptest.NewRecorder()
+			req, err = http.NewRequestWithContext(ctx, "GET", url, nil)
+			assert.NoError(t, err)
+
+			serv.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Test Stream Notes", func(t *testing.T) {
+		bus := events.New(config.Events{History: 16, Buffer: 16})
+		ts := httptest.NewServer(ginserver.New(mockApp, cfg, logg, server.WithEvents(bus)))
+		defer ts.Close()
+
+		first := bus.Publish(models.NoteEvent{Type: models.NoteCreated, NoteID: 5, Note: &models.Note{ID: 5}})
+		second := bus.Publish(models.NoteEvent{Type: models.NoteDue, NoteID: 5, Note: &models.Note{ID: 5}})
+		bus.Publish(models.NoteEvent{Type: models.NoteDeleted, NoteID: 5})
+
+		ctxS, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctxS, "GET", ts.URL+"/notes/stream?types=due", nil)
+		require.NoError(t, err)
+		req.Header.Set("Last-Event-ID", first.ID)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		var lines []string
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() && !strings.HasPrefix(sc.Text(), "data: ") {
+			lines = append(lines, sc.Text())
+		}
+		assert.Contains(t, lines, "id: "+second.ID)
+		assert.Contains(t, lines, "event: due")
+
+		wsURL := "ws" + strings.TrimPrefix(ts.URL, "http") + "/notes/stream?last_event_id=" + second.ID
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		var e models.NoteEvent
+		require.NoError(t, conn.ReadJSON(&e))
+		assert.Equal(t, models.NoteDeleted, e.Type)
+
+		for url, code := range map[string]int{
+			"/notes/stream?types=moved":       http.StatusBadRequest,
+			"/notes/stream?last_event_id=x-1": http.StatusGone,
+		} {
+			resp, err := http.Get(ts.URL + url)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, code, resp.StatusCode)
+		}
+	})
+
 	t.Run("Test Create Note", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		tm, err := time.Parse("02.01.2006 15:04", "14.01.2024 11:03")
@@ -84,7 +251,9 @@ func TestBasic(t *testing.T) {
 			Delay:       time.Minute * 20,
 		}
 
-		mockStr.On("CreateNote", ctx, note).Return(nilError)
+		created := note
+		created.ID, created.Version = 21, 1
+		mockStr.On("CreateNote", ctx, note).Return(created, nilError)
 
 		note.DateNotify = tm
 		b, err := json.Marshal(note)
@@ -97,6 +266,22 @@ func TestBasic(t *testing.T) {
 
 		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
 		assert.NoError(t, err)
+		assert.NotEmpty(t, w.Result().Header.Get("Deprecation"))
+		assert.Equal(t, "Fri, 30 Apr 2027 00:00:00 GMT", w.Result().Header.Get("Sunset"))
+
+		w = httptest.NewRecorder()
+		req, err = http.NewRequestWithContext(ctx, "POST", "/v1/notes", bytes.NewReader(b))
+		assert.NoError(t, err)
+
+		serv.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Result().StatusCode)
+		assert.Equal(t, "/v1/notes/21", w.Result().Header.Get("Location"))
+		assert.Equal(t, `"1"`, w.Result().Header.Get("ETag"))
+		assert.Empty(t, w.Result().Header.Get("Deprecation"))
+		var got models.Note
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, uint64(21), got.ID)
 	})
 
 	t.Run("Test Get Note", func(t *testing.T) {
@@ -110,6 +295,7 @@ func TestBasic(t *testing.T) {
 			DateAdded:   tm,
 			DateNotify:  tm,
 			Delay:       time.Minute * 20,
+			Version:     4,
 		}
 
 		mockStr.On("GetNote", ctx, note.ID).Return(note, nilError)
@@ -120,6 +306,7 @@ func TestBasic(t *testing.T) {
 		serv.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Equal(t, `"4"`, w.Result().Header.Get("ETag"))
 		assert.NoError(t, err)
 
 		noteRes := models.Note{}
@@ -141,10 +328,11 @@ func TestBasic(t *testing.T) {
 			Delay:       time.Minute * 20,
 		}
 
-		mockStr.On("DeleteNote", ctx, note.ID).Return(nilError)
+		mockStr.On("DeleteNote", ctx, note.ID, uint64(0)).Return(nilError)
 
 		req, err := http.NewRequestWithContext(ctx, "DELETE", "/notes/?id=1", nil)
 		assert.NoError(t, err)
+		req.Header.Set("If-Match", "*")
 
 		serv.ServeHTTP(w, req)
 
@@ -152,6 +340,27 @@ func TestBasic(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Test Delete Note Preconditions", func(t *testing.T) {
+		mockStr.On("DeleteNote", ctx, uint64(2), uint64(3)).Return(storage.ErrVersionConflict)
+
+		for ifMatch, code := range map[string]int{
+			"":      http.StatusPreconditionRequired,
+			`"x"`:   http.StatusBadRequest,
+			`W/"3"`: http.StatusPreconditionFailed,
+		} {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequestWithContext(ctx, "DELETE", "/notes/?id=2", nil)
+			assert.NoError(t, err)
+			if ifMatch != "" {
+				req.Header.Set("If-Match", ifMatch)
+			}
+
+			serv.ServeHTTP(w, req)
+
+			assert.Equal(t, code, w.Result().StatusCode, ifMatch)
+		}
+	})
+
 	t.Run("Test Update Note", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		tm, err := time.Parse("02.01.2006 15:04", "14.01.2024 11:03")
@@ -164,19 +373,257 @@ func TestBasic(t *testing.T) {
 			DateNotify:  tm,
 		}
 
-		mockStr.On("UpdateNote", ctx, note).Return(nilError)
-
 		b, err := json.Marshal(note)
 		assert.NoError(t, err)
 
+		fields := []models.NoteField{models.FieldTitle, models.FieldDescription, models.FieldDateNotify}
+		note.Version = 4
+		mockStr.On("UpdateNote", ctx, note, fields).Return(nilError)
+
 		req, err := http.NewRequestWithContext(ctx, "PATCH", "/notes/", bytes.NewReader(b))
 		assert.NoError(t, err)
+		req.Header.Set("If-Match", `"4"`)
 
 		serv.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+		assert.Equal(t, `"5"`, w.Result().Header.Get("ETag"))
+		assert.NoError(t, err)
+
+		note.Version = 3
+		mockStr.On("UpdateNote", ctx, note, fields).Return(storage.ErrVersionConflict)
+
+		w = httptest.NewRecorder()
+		req, err = http.NewRequestWithContext(ctx, "PATCH", "/notes/", bytes.NewReader(b))
+		assert.NoError(t, err)
+		req.Header.Set("If-Match", `"3"`)
+
+		serv.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Result().StatusCode)
+	})
+
+	t.Run("Test Merge Patch Note", func(t *testing.T) {
+		mockStr.On("UpdateNote", ctx, models.Note{ID: 2, Tags: []string{}, Version: 7},
+			[]models.NoteField{models.FieldDescription, models.FieldDelay, models.FieldTags}).Return(nilError)
+
+		for body, code := range map[string]int{
+			`{"id":2,"description":null,"delay":0,"tags":null}`: http.StatusNoContent,
+			`{"description":"no id"}`:                           http.StatusBadRequest,
+			`{"id":2,"dateAdded":"2024-01-14T11:03:00Z"}`:       http.StatusBadRequest,
+		} {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequestWithContext(ctx, "PATCH", "/notes/", strings.NewReader(body))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", ginserver.MergePatchContentType)
+			req.Header.Set("If-Match", `"7"`)
+
+			serv.ServeHTTP(w, req)
+
+			assert.Equal(t, code, w.Result().StatusCode, body)
+		}
+	})
+
+	t.Run("Test Idempotency Key", func(t *testing.T) {
+		idemStr := new(storage.MockStorage)
+		keys := idempotency.New(idemStr, config.Idempotency{TTL: time.Hour})
+		serv := ginserver.New(app.NewApp(idemStr), cfg, logg, server.WithIdempotency(keys))
+
+		fp := idempotency.Fingerprint([]byte("DELETE"), []byte("/notes/?id=9"), nil)
+		stored := idempotency.Record{Key: "k1", Fingerprint: fp, Done: true, Code: http.StatusNoContent}
+		idemStr.On("ReserveIdempotencyKey", ctx, mock.Anything, time.Hour).Return(idempotency.Record{}, true, nilError).Once()
+		idemStr.On("ReserveIdempotencyKey", ctx, mock.Anything, time.Hour).Return(stored, false, nilError).Once()
+		idemStr.On("ReserveIdempotencyKey", ctx, mock.Anything, time.Hour).
+			Return(idempotency.Record{Key: "k1", Fingerprint: []byte("other"), Done: true}, false, nilError).Once()
+		idemStr.On("DeleteNote", ctx, uint64(9), uint64(0)).Return(nilError).Once()
+		idemStr.On("SaveIdempotencyKey", ctx, mock.MatchedBy(func(r idempotency.Record) bool {
+			return r.Key == "k1" && r.Done && r.Code == http.StatusNoContent && string(r.Fingerprint) == string(fp)
+		})).Return(nilError).Once()
+
+		for i, code := range []int{http.StatusNoContent, http.StatusNoContent, http.StatusUnprocessableEntity} {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequestWithContext(ctx, "DELETE", "/notes/?id=9", nil)
+			assert.NoError(t, err)
+			req.Header.Set("If-Match", "*")
+			req.Header.Set(idempotency.HeaderKey, "k1")
+
+			serv.ServeHTTP(w, req)
+
+			assert.Equal(t, code, w.Result().StatusCode)
+			if i == 1 {
+				assert.Equal(t, "true", w.Result().Header.Get(idempotency.HeaderReplayed))
+			}
+		}
+		idemStr.AssertExpectations(t)
+	})
+
+	t.Run("Test Batch Notes", func(t *testing.T) {
+		refs := []models.NoteRef{{ID: 11}, {ID: 12, Version: 2}}
+		mockStr.On("DeleteNotes", ctx, refs, models.BatchPartial).Return([]models.BatchResult{
+			{ID: 11},
+			{ID: 12, Err: storage.ErrVersionConflict},
+		}, nilError)
+		updates := []models.NoteUpdate{{Note: models.Note{ID: 13, Title: "new"}, Fields: []models.NoteField{models.FieldTitle}}}
+		mockStr.On("UpdateNotes", ctx, updates, models.BatchAtomic).
+			Return([]models.BatchResult(nil), &storage.BatchError{Index: 0, Err: storage.ErrNotFound})
+
+		for body, code := range map[string]int{
+			`{"mode":"partial","delete":[{"id":11},{"id":12,"version":2}]}`: http.StatusOK,
+			`{"update":[{"note":{"id":13,"title":"new"}}]}`:                 http.StatusNotFound,
+			`{"delete":[{"id":11}],"update":[{"note":{"id":13}}]}`:          http.StatusBadRequest,
+			`{"mode":"all","delete":[{"id":11}]}`:                           http.StatusBadRequest,
+		} {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequestWithContext(ctx, "POST", "/notes:batch", strings.NewReader(body))
+			assert.NoError(t, err)
+
+			serv.ServeHTTP(w, req)
+
+			assert.Equal(t, code, w.Result().StatusCode, body)
+			if code == http.StatusOK {
+				assert.JSONEq(t, `{"results":[{"id":11,"status":200},
+					{"id":12,"status":412,"error":"note was changed concurrently"}]}`, w.Body.String())
+			}
+		}
+
+		w := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(ctx, "POST", "/notes:unknown", strings.NewReader("{}"))
 		assert.NoError(t, err)
+		serv.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	})
+
+	t.Run("Test V1 Note Routes", func(t *testing.T) {
+		tm := time.Date(2024, 1, 14, 11, 3, 0, 0, time.UTC)
+		mockStr.On("GetNote", ctx, uint64(31)).Return(models.Note{ID: 31, Version: 2}, nilError)
+		mockStr.On("UpdateNote", ctx, models.Note{ID: 31, Title: "patched", Version: 2},
+			[]models.NoteField{models.FieldTitle}).Return(nilError)
+		mockStr.On("UpdateNote", ctx, models.Note{ID: 31, Title: "put", DateNotify: tm, Tags: []string{}, Version: 2},
+			[]models.NoteField{models.FieldTitle, models.FieldDescription, models.FieldDateNotify,
+				models.FieldDelay, models.FieldTags}).Return(nilError)
+		mockStr.On("DeleteNote", ctx, uint64(31), uint64(2)).Return(nilError)
+		mockStr.On("RefreshNote", ctx, uint64(31), app.MaxRefreshDelay).
+			Return(models.Note{ID: 31, Version: 3}, false, nilError)
+		mockStr.On("RefreshNote", ctx, uint64(32), app.MaxRefreshDelay).Return(models.Note{}, true, nilError)
+		snoozed := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockStr.On("SnoozeNote", ctx, uint64(31), snoozed).Return(models.Note{ID: 31, DateNotify: snoozed}, nilError)
+		mockStr.On("AcknowledgeNote", ctx, uint64(31)).Return(models.Note{ID: 31, AcknowledgedAt: &tm}, nilError)
+		mockStr.On("AcknowledgeNote", ctx, uint64(33)).Return(models.Note{}, storage.ErrNotFound)
+
+		for _, tc := range []struct {
+			method, path, body string
+			code               int
+		}{
+			{"GET", "/v1/notes/31", "", http.StatusOK},
+			{"GET", "/v1/notes/x", "", http.StatusBadRequest},
+			{"PATCH", "/v1/notes/31", `{"title":"patched"}`, http.StatusNoContent},
+			{"PATCH", "/v1/notes/31", `{"id":32,"title":"patched"}`, http.StatusBadRequest},
+			{"PUT", "/v1/notes/31", `{"title":"put","dateNotify":"2024-01-14T11:03:00Z"}`, http.StatusNoContent},
+			{"DELETE", "/v1/notes/31", "", http.StatusNoContent},
+			{"POST", "/v1/notes/31:refresh", "", http.StatusOK},
+			{"POST", "/v1/notes/32:refresh", "", http.StatusNoContent},
+			{"POST", "/v1/notes/31:snooze", `{"until":"2099-01-01T00:00:00Z"}`, http.StatusOK},
+			{"POST", "/v1/notes/31:snooze", `{"until":"2099-01-01T00:00:00Z","for":"1h"}`, http.StatusBadRequest},
+			{"POST", "/v1/notes/31:snooze", `{"for":"-1h"}`, http.StatusBadRequest},
+			{"POST", "/v1/notes/31:acknowledge", "", http.StatusOK},
+			{"POST", "/v1/notes/33:acknowledge", "", http.StatusNotFound},
+			{"POST", "/v1/notes/31:unknown", "", http.StatusNotFound},
+		} {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequestWithContext(ctx, tc.method, tc.path, strings.NewReader(tc.body))
+			assert.NoError(t, err)
+			req.Header.Set("If-Match", `"2"`)
+
+			serv.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Result().StatusCode, tc.method+" "+tc.path)
+		}
+	})
+
+	t.Run("Test Health", func(t *testing.T) {
+		for _, path := range []string{"/healthz", "/readyz"} {
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequestWithContext(ctx, "GET", path, nil)
+			assert.NoError(t, err)
+
+			serv.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		}
+	})
+
+	t.Run("Test Rate Limit", func(t *testing.T) {
+		limiter := ratelimit.New(config.RateLimit{
+			Routes: map[string]config.Limit{"GET /v1/tags": {Rate: 0.001, Burst: 1}},
+		})
+		serv := ginserver.New(mockApp, cfg, logg, server.WithRateLimiter(limiter))
+		mockStr.On("ListTags", ctx).Return([]models.TagCount{}, nilError)
+
+		codes := make([]int, 2)
+		for i := range codes {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/v1/tags", nil)
+			require.NoError(t, err)
+			// Without trusted proxies, a new forwarded address doesn't get a new bucket.
+			req.RemoteAddr = "192.0.2.1:1234"
+			req.Header.Set("X-Forwarded-For", fmt.Sprintf("198.51.100.%d", i))
+			serv.ServeHTTP(w, req)
+			codes[i] = w.Result().StatusCode
+			if i == 1 {
+				assert.NotEmpty(t, w.Header().Get("Retry-After"))
+			}
+		}
+		assert.Equal(t, []int{http.StatusOK, http.StatusTooManyRequests}, codes)
+	})
+
+	t.Run("Test Connect Handler", func(t *testing.T) {
+		var got string
+		serv := ginserver.New(mockApp, cfg, logg, server.WithConnect("/gprc_notes.Notes/",
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.URL.Path
+				w.WriteHeader(http.StatusNoContent)
+			})))
+
+		w := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/gprc_notes.Notes/GetNote", nil)
+		require.NoError(t, err)
+		serv.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+		assert.Equal(t, "/gprc_notes.Notes/GetNote", got)
 	})
 
 	mockStr.AssertExpectations(t)
 }
+
+func TestMetrics(t *testing.T) {
+	mockStr := new(storage.MockStorage)
+	logg, err := logger.New(logger.EnvLocal)
+	require.NoError(t, err)
+
+	serv := ginserver.New(app.NewApp(mockStr), config.Server{ShutDownTimeout: 5}, logg)
+	ctx := context.Background()
+
+	mockStr.On("GetNote", ctx, uint64(41)).Return(models.Note{ID: 41, Version: 1}, nilError).Once()
+	mockStr.On("GetNote", ctx, uint64(42)).Return(models.Note{}, storage.ErrNotFound).Once()
+	for _, path := range []string{"/v1/notes/41", "/v1/notes/42", "/v1/unknown"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+		require.NoError(t, err)
+		serv.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/metrics", nil)
+	require.NoError(t, err)
+	promhttp.Handler().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	body := w.Body.String()
+	assert.Contains(t, body, `notes_http_requests_total{method="GET",route="/v1/notes/:id",status="200"}`)
+	assert.Contains(t, body, `notes_http_requests_total{method="GET",route="/v1/notes/:id",status="404"}`)
+	assert.Contains(t, body, `notes_http_requests_total{method="GET",route="unmatched",status="404"}`)
+	assert.Contains(t, body,
+		`notes_http_request_duration_seconds_count{method="GET",route="/v1/notes/:id",status="200"}`)
+	mockStr.AssertExpectations(t)
+}
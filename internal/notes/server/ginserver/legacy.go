@@ -0,0 +1,74 @@
+package ginserver
+
+import (
+	"errors"
+	"net/http"
+	"notes/internal/notes/server/ginserver/middlewares"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// The routes before /v1 are deprecated since LegacyDeprecation and will be removed
+// after LegacySunset.
+var (
+	LegacyDeprecation = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	LegacySunset      = time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)
+)
+
+var ErrNoNoteID = errors.New("note id is required")
+
+// registerLegacy keeps the routes before /v1 as aliases that announce their removal.
+func (s *Server) registerLegacy(e *gin.Engine, idempotent []gin.HandlerFunc) {
+	legacy := e.Group("", append([]gin.HandlerFunc{
+		middlewares.DeprecationMiddleware(LegacyDeprecation, LegacySunset, "/v1"),
+	}, idempotent...)...)
+
+	notes := legacy.Group("/notes")
+	notes.GET("/", s.GetNotes)
+	notes.GET("/search", s.SearchNotes)
+	notes.GET("/stream", s.StreamNotes)
+	notes.GET("/:id", s.GetNote)
+	notes.PUT("/", s.CreateNote)
+	notes.DELETE("/", s.LegacyDeleteNote)
+	notes.PATCH("/", s.LegacyUpdateNote)
+	legacy.POST("/notes:action", s.NotesAction)
+
+	legacy.GET("/tags", s.ListTags)
+}
+
+// LegacyDeleteNote serves DELETE /notes/?id=1, see DeleteNote.
+func (s *Server) LegacyDeleteNote(c *gin.Context) {
+	idS, ok := c.GetQuery("id")
+	if !ok {
+		c.AbortWithStatus(http.StatusNotAcceptable)
+		return
+	}
+	id, ok := noteID(c, idS)
+	if !ok {
+		return
+	}
+	s.deleteNote(c, id)
+}
+
+// LegacyUpdateNote serves PATCH /notes/ with the note id in the body, see UpdateNote.
+// With the "Refreshed: true" header it refreshes the note instead, see NoteAction.
+func (s *Server) LegacyUpdateNote(c *gin.Context) {
+	n, fields, ok := patchBody(c)
+	if !ok {
+		return
+	}
+	if n.ID == 0 {
+		c.AbortWithError(http.StatusBadRequest, ErrNoNoteID)
+		return
+	}
+
+	switch r := c.GetHeader("Refreshed"); r {
+	case "":
+		s.updateNote(c, n, fields)
+	case "true":
+		s.refreshNote(c, n.ID)
+	default:
+		c.AbortWithStatus(http.StatusBadRequest)
+	}
+}
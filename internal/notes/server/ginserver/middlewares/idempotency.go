@@ -0,0 +1,102 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"notes/internal/notes/idempotency"
+	"notes/internal/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// recordingWriter keeps a copy of the response body.
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware executes mutating requests with an Idempotency-Key header
+// once. Retries get the stored response of a successful request, a key reused for
+// another request is rejected with 422 and one whose request is still running with 409.
+// Failed requests are not stored, so they can be retried with the same key.
+func IdempotencyMiddleware(k *idempotency.Keys, logg logger.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(idempotency.HeaderKey)
+		switch ctx.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			key = ""
+		}
+		if key == "" {
+			ctx.Next()
+			return
+		}
+
+		var body []byte
+		if ctx.Request.Body != nil {
+			var err error
+			if body, err = io.ReadAll(ctx.Request.Body); err != nil {
+				ctx.AbortWithError(http.StatusBadRequest, err)
+				return
+			}
+			ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		fp := idempotency.Fingerprint([]byte(ctx.Request.Method), []byte(ctx.Request.URL.RequestURI()), body)
+		r, replay, err := k.Begin(ctx.Request.Context(), key, ctx.ClientIP(), fp)
+		switch {
+		case errors.Is(err, idempotency.ErrInvalidKey):
+			ctx.AbortWithError(http.StatusBadRequest, err)
+			return
+		case errors.Is(err, idempotency.ErrKeyMismatch):
+			ctx.AbortWithError(http.StatusUnprocessableEntity, err)
+			return
+		case errors.Is(err, idempotency.ErrInProgress):
+			ctx.AbortWithError(http.StatusConflict, err)
+			return
+		case err != nil:
+			ctx.AbortWithError(http.StatusInternalServerError, err)
+			return
+		case replay:
+			for name, v := range r.Header {
+				ctx.Writer.Header()[name] = v
+			}
+			ctx.Header(idempotency.HeaderReplayed, "true")
+			ctx.Status(r.Code)
+			_, _ = ctx.Writer.Write(r.Body)
+			ctx.Abort()
+			return
+		}
+
+		w := &recordingWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = w
+		ctx.Next()
+
+		// The result is kept even when the client has gone away.
+		saveCtx := context.WithoutCancel(ctx.Request.Context())
+		if code := w.Status(); code >= http.StatusOK && code < http.StatusMultipleChoices {
+			r.Code, r.Header, r.Body = code, w.Header().Clone(), w.body.Bytes()
+			err := k.Finish(saveCtx, r)
+			if err == nil {
+				return
+			}
+			logg.Errorf("storing idempotency key %q failed: %s", key, err)
+		}
+		// A key left without a result would block retries until it expires.
+		if err := k.Abandon(saveCtx, r); err != nil {
+			logg.Errorf("releasing idempotency key %q failed: %s", key, err)
+		}
+	}
+}
@@ -1,10 +1,41 @@
 package middlewares
 
 import (
+	"errors"
+	"net/http"
+	"notes/internal/notes/ratelimit"
+	"notes/internal/pkg/auth"
 	"notes/internal/pkg/logger"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "notes",
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of REST API requests by route, method and status.",
+	}, []string{"route", "method", "status"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "notes",
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of REST API requests by route, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
 )
 
 func LoggingMiddleware(logg logger.Logger) gin.HandlerFunc {
@@ -33,3 +64,107 @@ func LoggingMiddleware(logg logger.Logger) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+func TracingMiddleware() gin.HandlerFunc {
+	tracer := otel.Tracer("notes/internal/notes/server/ginserver")
+	return func(ctx *gin.Context) {
+		reqCtx := otel.GetTextMapPropagator().Extract(ctx.Request.Context(),
+			propagation.HeaderCarrier(ctx.Request.Header))
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = ctx.Request.URL.Path
+		}
+
+		reqCtx, span := tracer.Start(reqCtx, ctx.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(ctx.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(ctx.Request.URL.Path),
+				semconv.ClientAddress(ctx.ClientIP()),
+				semconv.UserAgentOriginal(ctx.Request.UserAgent()),
+			),
+		)
+		defer span.End()
+
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+		ctx.Next()
+
+		statusCode := ctx.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(statusCode))
+		if statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(statusCode))
+		}
+		for _, err := range ctx.Errors {
+			span.RecordError(err.Err)
+		}
+	}
+}
+
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		startTime := time.Now()
+
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		statusCode := strconv.Itoa(ctx.Writer.Status())
+
+		requestsTotal.WithLabelValues(route, ctx.Request.Method, statusCode).Inc()
+		requestDuration.WithLabelValues(route, ctx.Request.Method, statusCode).
+			Observe(time.Since(startTime).Seconds())
+	}
+}
+
+func AuthMiddleware(a *auth.Authenticator) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		c := auth.ParseCredentials(
+			ctx.GetHeader(auth.HeaderAuthorization),
+			ctx.GetHeader(auth.HeaderAPIKey),
+		)
+
+		p, err := a.Authenticate(ctx.Request.Context(), c)
+		if err != nil {
+			ctx.Header("WWW-Authenticate", `Bearer realm="notes"`)
+			ctx.AbortWithError(http.StatusUnauthorized, err)
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(auth.NewContext(ctx.Request.Context(), p))
+		ctx.Next()
+	}
+}
+
+// RateLimitMiddleware answers 429 with Retry-After when the caller has run out
+// of requests on the route. It must run after AuthMiddleware.
+func RateLimitMiddleware(l *ratelimit.Limiter) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		route := ctx.Request.Method + " " + ctx.FullPath()
+		ok, wait := l.Allow(route, ratelimit.Caller(ctx.Request.Context(), ctx.ClientIP()))
+		if !ok {
+			ctx.Header("Retry-After", ratelimit.RetryAfter(wait))
+			ctx.AbortWithError(http.StatusTooManyRequests, ErrRateLimited)
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// DeprecationMiddleware announces that a route is deprecated since deprecation and
+// will be removed after sunset (RFC 9745 and RFC 8594), pointing to its successor.
+func DeprecationMiddleware(deprecation, sunset time.Time, successor string) gin.HandlerFunc {
+	dep := "@" + strconv.FormatInt(deprecation.Unix(), 10)
+	sun := sunset.UTC().Format(http.TimeFormat)
+	link := "<" + successor + `>; rel="successor-version"`
+	return func(ctx *gin.Context) {
+		h := ctx.Writer.Header()
+		h.Set("Deprecation", dep)
+		h.Set("Sunset", sun)
+		h.Add("Link", link)
+		ctx.Next()
+	}
+}
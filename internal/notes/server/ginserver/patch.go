@@ -0,0 +1,70 @@
+package ginserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"notes/internal/pkg/models"
+)
+
+// MergePatchContentType marks a PATCH body as a JSON Merge Patch (RFC 7396).
+const MergePatchContentType = "application/merge-patch+json"
+
+var ErrBadPatch = errors.New("bad merge patch")
+
+// mergePatchMembers are the members of a note merge patch in the order their
+// fields are applied. null resets a field to its zero value.
+var mergePatchMembers = []struct {
+	name  string
+	field models.NoteField
+	value func(*models.Note) any
+}{
+	{"title", models.FieldTitle, func(n *models.Note) any { return &n.Title }},
+	{"description", models.FieldDescription, func(n *models.Note) any { return &n.Description }},
+	{"dateNotify", models.FieldDateNotify, func(n *models.Note) any { return &n.DateNotify }},
+	{"delay", models.FieldDelay, func(n *models.Note) any { return &n.Delay }},
+	{"tags", models.FieldTags, func(n *models.Note) any { return &n.Tags }},
+}
+
+// mergePatch reads a merge patch of a note. The note may be identified by the "id"
+// member, the other members present in the patch are the fields to update.
+func mergePatch(r io.Reader) (models.Note, []models.NoteField, error) {
+	var (
+		n   models.Note
+		doc map[string]json.RawMessage
+	)
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return n, nil, fmt.Errorf("%w: %s", ErrBadPatch, err)
+	}
+
+	if id, ok := doc["id"]; ok {
+		if err := json.Unmarshal(id, &n.ID); err != nil {
+			return n, nil, fmt.Errorf("%w: id: %s", ErrBadPatch, err)
+		}
+		delete(doc, "id")
+	}
+
+	fields := make([]models.NoteField, 0, len(doc))
+	for _, m := range mergePatchMembers {
+		raw, ok := doc[m.name]
+		if !ok {
+			continue
+		}
+		delete(doc, m.name)
+
+		if !bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			if err := json.Unmarshal(raw, m.value(&n)); err != nil {
+				return n, nil, fmt.Errorf("%w: %s: %s", ErrBadPatch, m.name, err)
+			}
+		} else if m.field == models.FieldTags {
+			n.Tags = []string{}
+		}
+		fields = append(fields, m.field)
+	}
+	for name := range doc {
+		return n, nil, fmt.Errorf("%w: %s can't be patched", ErrBadPatch, name)
+	}
+	return n, fields, nil
+}
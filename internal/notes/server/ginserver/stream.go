@@ -0,0 +1,213 @@
+package ginserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"notes/internal/notes/events"
+	"notes/internal/pkg/models"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// streamHeartbeat keeps idle streams from being cut by proxies.
+	streamHeartbeat = time.Second * 15
+	// streamWriteTimeout drops clients that stop reading.
+	streamWriteTimeout = time.Second * 10
+)
+
+var upgrader = websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 4096}
+
+// StreamNotes serves GET /notes/stream?types=created,due&tags=work&last_event_id=...
+// Events are pushed as server-sent events, or as JSON messages when the request asks
+// for a WebSocket upgrade. The Last-Event-ID header overrides last_event_id.
+//
+// Clients that fall behind are disconnected rather than buffered for, and should
+// reconnect with the ID of the last event they handled.
+func (s *Server) StreamNotes(c *gin.Context) {
+	if s.opts.Events == nil {
+		c.AbortWithStatus(http.StatusNotImplemented)
+		return
+	}
+
+	match, err := streamFilter(c)
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	lastID := c.GetHeader("Last-Event-ID")
+	if lastID == "" {
+		lastID = c.Query("last_event_id")
+	}
+	sub, err := s.opts.Events.Subscribe(lastID, match)
+	if err != nil {
+		if errors.Is(err, events.ErrResumeExpired) {
+			c.AbortWithError(http.StatusGone, err)
+			return
+		}
+		c.AbortWithError(http.StatusServiceUnavailable, err)
+		return
+	}
+	defer sub.Close()
+
+	if websocket.IsWebSocketUpgrade(c.Request) {
+		s.streamWebSocket(c, sub)
+		return
+	}
+	s.streamSSE(c, sub)
+}
+
+// streamFilter limits the events to the caller's notes, the requested types and,
+// when tags are given, to notes with any of them. Deleted notes carry no tags, so
+// their events pass the tag filter.
+func streamFilter(c *gin.Context) (func(models.NoteEvent) bool, error) {
+	var types []models.NoteEventType
+	for _, t := range c.QueryArray("types") {
+		for _, t := range strings.Split(t, ",") {
+			switch et := models.NoteEventType(t); et {
+			case models.NoteCreated, models.NoteUpdated, models.NoteDeleted, models.NoteDue:
+				types = append(types, et)
+			default:
+				return nil, fmt.Errorf("%w: types=%s", ErrBadQuery, t)
+			}
+		}
+	}
+
+	var tags []string
+	for _, t := range c.QueryArray("tags") {
+		tags = append(tags, strings.Split(t, ",")...)
+	}
+	if len(tags) > 0 {
+		tags = models.NormalizeTags(tags)
+	}
+
+	match := events.ForContext(c.Request.Context(), types...)
+	return func(e models.NoteEvent) bool {
+		if !match(e) {
+			return false
+		}
+		if len(tags) == 0 || e.Note == nil {
+			return true
+		}
+		for _, t := range e.Note.Tags {
+			for _, want := range tags {
+				if t == want {
+					return true
+				}
+			}
+		}
+		return false
+	}, nil
+}
+
+func (s *Server) streamSSE(c *gin.Context, sub *events.Subscription) {
+	w := c.Writer
+	rc := http.NewResponseController(w)
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	write := func(msg string) error {
+		if err := rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err != nil &&
+			!errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		if _, err := io.WriteString(w, msg); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+	// Clients retry after 3s and the first write commits the headers.
+	if err := write("retry: 3000\n\n"); err != nil {
+		return
+	}
+
+	hb := time.NewTicker(streamHeartbeat)
+	defer hb.Stop()
+	for {
+		var msg string
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-hb.C:
+			msg = ": heartbeat\n\n"
+		case e, ok := <-sub.Events():
+			if !ok {
+				_ = write(fmt.Sprintf("event: error\ndata: %s\n\n", sub.Err()))
+				return
+			}
+			b, err := json.Marshal(e)
+			if err != nil {
+				s.logg.Errorf("marshal note event: %s", err)
+				continue
+			}
+			msg = fmt.Sprintf("id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, b)
+		}
+		if err := write(msg); err != nil {
+			s.logg.Debugf("note stream closed: %v\n", err)
+			return
+		}
+	}
+}
+
+func (s *Server) streamWebSocket(c *gin.Context, sub *events.Subscription) {
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade has replied with an error.
+		return
+	}
+	defer conn.Close()
+
+	// Clients only send pongs and the close handshake. The read loop handles them
+	// and tells when the client has gone away.
+	gone := make(chan struct{})
+	conn.SetReadLimit(512)
+	_ = conn.SetReadDeadline(time.Now().Add(streamHeartbeat * 2))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(streamHeartbeat * 2))
+	})
+	go func() {
+		defer close(gone)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	hb := time.NewTicker(streamHeartbeat)
+	defer hb.Stop()
+	for {
+		var err error
+		select {
+		case <-gone:
+			return
+		case <-hb.C:
+			err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout))
+		case e, ok := <-sub.Events():
+			if !ok {
+				msg := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, sub.Err().Error())
+				_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(streamWriteTimeout))
+				return
+			}
+			if err = conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err == nil {
+				err = conn.WriteJSON(e)
+			}
+		}
+		if err != nil {
+			s.logg.Debugf("note stream closed: %v\n", err)
+			return
+		}
+	}
+}
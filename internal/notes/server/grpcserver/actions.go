@@ -0,0 +1,63 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"notes/internal/notes/app"
+	"notes/internal/notes/server/grpcserver/pb"
+	"notes/internal/notes/storage"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) RefreshNote(ctx context.Context, req *pb.RefreshNoteRequest) (*pb.RefreshNoteResponse, error) {
+	note, deleted, err := s.a.RefreshNote(ctx, req.GetID())
+	if err != nil {
+		return &pb.RefreshNoteResponse{}, actionError(err)
+	}
+	if deleted {
+		return &pb.RefreshNoteResponse{Deleted: true}, nil
+	}
+	return &pb.RefreshNoteResponse{Note: ToPBNote(note)}, nil
+}
+
+func (s *Server) SnoozeNote(ctx context.Context, req *pb.SnoozeNoteRequest) (*pb.SnoozeNoteResponse, error) {
+	var until time.Time
+	switch v := req.GetSnooze().(type) {
+	case *pb.SnoozeNoteRequest_Until:
+		until = v.Until.AsTime()
+	case *pb.SnoozeNoteRequest_Duration:
+		until = time.Now().Add(v.Duration.AsDuration())
+	default:
+		return &pb.SnoozeNoteResponse{}, status.Error(codes.InvalidArgument, "until or duration is required")
+	}
+
+	note, err := s.a.SnoozeNote(ctx, req.GetID(), until)
+	if err != nil {
+		return &pb.SnoozeNoteResponse{}, actionError(err)
+	}
+	return &pb.SnoozeNoteResponse{Note: ToPBNote(note)}, nil
+}
+
+func (s *Server) AcknowledgeNote(ctx context.Context, req *pb.AcknowledgeNoteRequest,
+) (*pb.AcknowledgeNoteResponse, error) {
+	note, err := s.a.AcknowledgeNote(ctx, req.GetID())
+	if err != nil {
+		return &pb.AcknowledgeNoteResponse{}, actionError(err)
+	}
+	return &pb.AcknowledgeNoteResponse{Note: ToPBNote(note)}, nil
+}
+
+func actionError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, storage.ErrFieldUnspecified), errors.Is(err, app.ErrSnoozeInPast):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, app.ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
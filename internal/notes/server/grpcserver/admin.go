@@ -0,0 +1,219 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"notes/internal/notes/server"
+	"notes/internal/notes/server/grpcserver/pb"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/auth"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultUserTokenTTL is the lifetime of user tokens issued without a ttl.
+const defaultUserTokenTTL = time.Hour * 24
+
+type adminServer struct {
+	pb.UnimplementedAdminServer
+	info    *server.AdminInfo
+	stats   *connStats
+	started time.Time
+}
+
+func (s *adminServer) GetBuildInfo(context.Context, *pb.GetBuildInfoRequest) (*pb.GetBuildInfoResponse, error) {
+	res := &pb.GetBuildInfoResponse{Started: timestamppb.New(s.started)}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return res, nil
+	}
+
+	res.Version, res.GoVersion = bi.Main.Version, bi.GoVersion
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			res.Revision = setting.Value
+		case "vcs.time":
+			if t, err := time.Parse(time.RFC3339, setting.Value); err == nil {
+				res.RevisionTime = timestamppb.New(t)
+			}
+		case "vcs.modified":
+			res.Modified = setting.Value == "true"
+		}
+	}
+	return res, nil
+}
+
+func (s *adminServer) GetMigrationVersion(ctx context.Context, _ *pb.GetMigrationVersionRequest,
+) (*pb.GetMigrationVersionResponse, error) {
+	if s.info == nil || s.info.MigrationVersion == nil {
+		return &pb.GetMigrationVersionResponse{}, status.Error(codes.Unavailable, "migration version is unknown")
+	}
+	v, err := s.info.MigrationVersion(ctx)
+	if err != nil {
+		return &pb.GetMigrationVersionResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetMigrationVersionResponse{Applied: v, Expected: s.info.Config.DB.Version}, nil
+}
+
+func (s *adminServer) GetConfig(context.Context, *pb.GetConfigRequest) (*pb.GetConfigResponse, error) {
+	if s.info == nil {
+		return &pb.GetConfigResponse{}, status.Error(codes.Unavailable, "config is unknown")
+	}
+	b, err := yaml.Marshal(s.info.Config.Redacted())
+	if err != nil {
+		return &pb.GetConfigResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetConfigResponse{Yaml: string(b)}, nil
+}
+
+func (s *adminServer) GetConnectionStats(context.Context, *pb.GetConnectionStatsRequest,
+) (*pb.GetConnectionStatsResponse, error) {
+	return &pb.GetConnectionStatsResponse{
+		OpenConnections:  s.stats.open.Load(),
+		TotalConnections: s.stats.total.Load(),
+		RpcsInFlight:     s.stats.inFlight.Load(),
+		RpcsSucceeded:    s.stats.succeeded.Load(),
+		RpcsFailed:       s.stats.failed.Load(),
+	}, nil
+}
+
+func (s *adminServer) GetLogLevel(context.Context, *pb.GetLogLevelRequest) (*pb.GetLogLevelResponse, error) {
+	if s.info == nil {
+		return &pb.GetLogLevelResponse{}, status.Error(codes.Unavailable, "logger is unknown")
+	}
+	return &pb.GetLogLevelResponse{Level: s.info.Logger.Level().String()}, nil
+}
+
+func (s *adminServer) SetLogLevel(_ context.Context, req *pb.SetLogLevelRequest) (*pb.SetLogLevelResponse, error) {
+	if s.info == nil {
+		return &pb.SetLogLevelResponse{}, status.Error(codes.Unavailable, "logger is unknown")
+	}
+	var lvl zapcore.Level
+	if err := lvl.UnmarshalText([]byte(req.GetLevel())); err != nil {
+		return &pb.SetLogLevelResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	s.info.Logger.SetLevel(lvl)
+	s.info.Logger.Infof("log level set to %s", lvl)
+	return &pb.SetLogLevelResponse{Level: lvl.String()}, nil
+}
+
+func (s *adminServer) users() (server.UserStore, error) {
+	if s.info == nil || s.info.Users == nil {
+		return nil, status.Error(codes.Unavailable, "users are not provisioned by this service")
+	}
+	return s.info.Users, nil
+}
+
+// user returns the user id as a principal.
+func (s *adminServer) user(ctx context.Context, users server.UserStore, id uint64) (auth.Principal, error) {
+	u, err := users.GetUser(ctx, id)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return auth.Principal{}, status.Errorf(codes.NotFound, "user %d not found", id)
+	case err != nil:
+		return auth.Principal{}, status.Error(codes.Internal, err.Error())
+	}
+	return auth.Principal{ID: u.ID, Name: u.Name, Kind: auth.KindUser}, nil
+}
+
+func (s *adminServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
+	users, err := s.users()
+	if err != nil {
+		return &pb.CreateUserResponse{}, err
+	}
+	name := strings.TrimSpace(req.GetName())
+	if name == "" {
+		return &pb.CreateUserResponse{}, status.Error(codes.InvalidArgument, "name is empty")
+	}
+	u, err := users.CreateUser(ctx, name)
+	if err != nil {
+		return &pb.CreateUserResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	s.info.Logger.Infof("user %d %q created", u.ID, u.Name)
+	return &pb.CreateUserResponse{Id: u.ID, Name: u.Name, CreatedAt: timestamppb.New(u.CreatedAt)}, nil
+}
+
+func (s *adminServer) CreateAPIKey(ctx context.Context, req *pb.CreateAPIKeyRequest) (*pb.CreateAPIKeyResponse, error) {
+	users, err := s.users()
+	if err != nil {
+		return &pb.CreateAPIKeyResponse{}, err
+	}
+
+	var p auth.Principal
+	switch principal := req.GetPrincipal().(type) {
+	case *pb.CreateAPIKeyRequest_UserId:
+		if p, err = s.user(ctx, users, principal.UserId); err != nil {
+			return &pb.CreateAPIKeyResponse{}, err
+		}
+	case *pb.CreateAPIKeyRequest_Service:
+		if strings.TrimSpace(principal.Service) == "" {
+			return &pb.CreateAPIKeyResponse{}, status.Error(codes.InvalidArgument, "service is empty")
+		}
+		p = auth.Principal{Name: strings.TrimSpace(principal.Service), Kind: auth.KindService}
+	default:
+		return &pb.CreateAPIKeyResponse{}, status.Error(codes.InvalidArgument, "user_id or service is required")
+	}
+
+	key, err := users.CreateAPIKey(ctx, p)
+	if err != nil {
+		return &pb.CreateAPIKeyResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	s.info.Logger.Infof("api key created for %s %q", p.Kind, p.Name)
+	return &pb.CreateAPIKeyResponse{Key: key}, nil
+}
+
+func (s *adminServer) RevokeAPIKey(ctx context.Context, req *pb.RevokeAPIKeyRequest) (*pb.RevokeAPIKeyResponse, error) {
+	users, err := s.users()
+	if err != nil {
+		return &pb.RevokeAPIKeyResponse{}, err
+	}
+	if req.GetKey() == "" {
+		return &pb.RevokeAPIKeyResponse{}, status.Error(codes.InvalidArgument, "key is empty")
+	}
+	err = users.RevokeAPIKey(ctx, auth.HashAPIKey(req.GetKey()))
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return &pb.RevokeAPIKeyResponse{}, status.Error(codes.NotFound, "api key not found or already revoked")
+	case err != nil:
+		return &pb.RevokeAPIKeyResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	s.info.Logger.Info("api key revoked")
+	return &pb.RevokeAPIKeyResponse{}, nil
+}
+
+func (s *adminServer) IssueUserToken(ctx context.Context, req *pb.IssueUserTokenRequest,
+) (*pb.IssueUserTokenResponse, error) {
+	users, err := s.users()
+	if err != nil {
+		return &pb.IssueUserTokenResponse{}, err
+	}
+	if s.info.Tokens == nil {
+		return &pb.IssueUserTokenResponse{}, status.Error(codes.FailedPrecondition, "auth.jwtSecret is not set")
+	}
+	ttl := defaultUserTokenTTL
+	if req.GetTtl() != nil {
+		ttl = req.GetTtl().AsDuration()
+	}
+	if ttl <= 0 {
+		return &pb.IssueUserTokenResponse{}, status.Error(codes.InvalidArgument, "ttl must be positive")
+	}
+
+	p, err := s.user(ctx, users, req.GetUserId())
+	if err != nil {
+		return &pb.IssueUserTokenResponse{}, err
+	}
+	expires := time.Now().Add(ttl)
+	token, err := s.info.Tokens.IssueToken(p, ttl)
+	if err != nil {
+		return &pb.IssueUserTokenResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.IssueUserTokenResponse{Token: token, ExpiresAt: timestamppb.New(expires)}, nil
+}
@@ -0,0 +1,87 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"notes/internal/notes/app"
+	"notes/internal/notes/server/grpcserver/pb"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) BatchCreate(ctx context.Context, req *pb.BatchCreateRequest) (*pb.BatchCreateResponse, error) {
+	notes := make([]models.Note, len(req.GetNotes()))
+	for i, n := range req.GetNotes() {
+		notes[i] = ToNote(n)
+	}
+
+	create := s.a.CreateNotes
+	if req.GetKeepSchedule() {
+		create = s.a.ImportNotes
+	}
+	results, err := create(ctx, notes, ToBatchMode(req.GetMode()))
+	if err != nil {
+		return &pb.BatchCreateResponse{}, batchError(err)
+	}
+	return &pb.BatchCreateResponse{Results: ToPBBatchResults(results)}, nil
+}
+
+func (s *Server) BatchUpdate(ctx context.Context, req *pb.BatchUpdateRequest) (*pb.BatchUpdateResponse, error) {
+	updates := make([]models.NoteUpdate, len(req.GetUpdates()))
+	for i, u := range req.GetUpdates() {
+		fields, err := ToNoteFields(u.GetUpdateMask())
+		if err != nil {
+			return &pb.BatchUpdateResponse{}, batchError(&storage.BatchError{Index: i, Err: err})
+		}
+		updates[i] = models.NoteUpdate{Note: ToNote(u.GetNote()), Fields: fields}
+		updates[i].Note.Version = u.GetExpectedVersion()
+	}
+
+	results, err := s.a.UpdateNotes(ctx, updates, ToBatchMode(req.GetMode()))
+	if err != nil {
+		return &pb.BatchUpdateResponse{}, batchError(err)
+	}
+	return &pb.BatchUpdateResponse{Results: ToPBBatchResults(results)}, nil
+}
+
+func (s *Server) BatchDelete(ctx context.Context, req *pb.BatchDeleteRequest) (*pb.BatchDeleteResponse, error) {
+	refs := make([]models.NoteRef, len(req.GetNotes()))
+	for i, r := range req.GetNotes() {
+		refs[i] = models.NoteRef{ID: r.GetID(), Version: r.GetExpectedVersion()}
+	}
+
+	results, err := s.a.DeleteNotes(ctx, refs, ToBatchMode(req.GetMode()))
+	if err != nil {
+		return &pb.BatchDeleteResponse{}, batchError(err)
+	}
+	return &pb.BatchDeleteResponse{Results: ToPBBatchResults(results)}, nil
+}
+
+// batchError fails an atomic batch with the code of the item that rolled it back.
+func batchError(err error) error {
+	return status.Error(batchCode(err), err.Error())
+}
+
+func batchCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, storage.ErrVersionConflict):
+		return codes.Aborted
+	case errors.Is(err, storage.ErrInvalidField),
+		errors.Is(err, storage.ErrFieldUnspecified),
+		errors.Is(err, storage.ErrNotEnoughArguments),
+		errors.Is(err, app.ErrEmptyBatch),
+		errors.Is(err, app.ErrBatchTooLarge),
+		errors.Is(err, app.ErrInvalidBatchMode):
+		return codes.InvalidArgument
+	case errors.Is(err, app.ErrQuotaExceeded):
+		return codes.ResourceExhausted
+	case errors.Is(err, storage.ErrUnknownOwner):
+		return codes.PermissionDenied
+	}
+	return codes.Internal
+}
@@ -2,23 +2,40 @@ package grpcserver_test
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"notes/internal/notes/app"
+	"notes/internal/notes/events"
+	notesserver "notes/internal/notes/server"
 	"notes/internal/notes/server/grpcserver"
 	"notes/internal/notes/server/grpcserver/pb"
 	"notes/internal/notes/storage"
+	"notes/internal/pkg/auth"
 	"notes/internal/pkg/config"
 	"notes/internal/pkg/logger"
 	"notes/internal/pkg/models"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"bou.ke/monkey"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -31,7 +48,9 @@ func TestBasic(t *testing.T) {
 
 	mockApp := app.NewApp(mockStr)
 
-	server := grpcserver.New(mockApp, logg, config.GRPCServer{})
+	bus := events.New(config.Events{History: 16, Buffer: 16})
+	server, err := grpcserver.New(mockApp, logg, config.GRPCServer{}, notesserver.WithEvents(bus))
+	require.NoError(t, err)
 	s := grpc.NewServer()
 
 	pb.RegisterNotesServer(s, server)
@@ -60,9 +79,8 @@ func TestBasic(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("Test Get Notes", func(t *testing.T) {
-		exp := []models.Note{}
-		var td time.Duration
-		mockStr.On("GetNotes", ctx, td).Return(exp, nilError)
+		exp := models.NotesPage{Notes: []models.Note{}}
+		mockStr.On("GetNotes", ctx, models.NotesFilter{PageSize: app.DefaultPageSize}).Return(exp, nilError)
 
 		res, err := client.GetNotes(ctx, &pb.GetNotesRequest{})
 
@@ -70,6 +88,115 @@ func TestBasic(t *testing.T) {
 		assert.Len(t, res.GetNotes(), 0)
 	})
 
+	t.Run("Test Get Notes With tags", func(t *testing.T) {
+		exp := models.NotesPage{Notes: []models.Note{{ID: 2, Title: "test", Tags: []string{"work"}}}}
+		filter := models.NotesFilter{Tags: []string{"home", "work"}, PageSize: app.DefaultPageSize}
+		mockStr.On("GetNotes", ctx, filter).Return(exp, nilError)
+
+		res, err := client.GetNotes(ctx, &pb.GetNotesRequest{
+			Tags:     []string{"work", "home"},
+			TagMatch: pb.TagMatch_TAG_MATCH_ANY,
+		})
+
+		assert.NoError(t, err)
+		require.Len(t, res.GetNotes(), 1)
+		assert.Equal(t, []string{"work"}, res.GetNotes()[0].Tags)
+	})
+
+	t.Run("Test Get Notes Paginated", func(t *testing.T) {
+		exp := models.NotesPage{Notes: []models.Note{{ID: 7, Title: "test"}}, NextPageToken: "next"}
+		filter := models.NotesFilter{SortBy: models.SortByDateAdded, PageSize: app.MaxPageSize, PageToken: "prev"}
+		mockStr.On("GetNotes", ctx, filter).Return(exp, nilError)
+
+		res, err := client.GetNotes(ctx, &pb.GetNotesRequest{
+			SortBy:    pb.NotesSort_NOTES_SORT_DATE_ADDED,
+			PageSize:  1000,
+			PageToken: "prev",
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, res.GetNotes(), 1)
+		assert.Equal(t, "next", res.GetNextPageToken())
+
+		filter = models.NotesFilter{PageSize: app.DefaultPageSize, PageToken: "bad"}
+		mockStr.On("GetNotes", ctx, filter).Return(models.NotesPage{}, storage.ErrInvalidPageToken)
+
+		_, err = client.GetNotes(ctx, &pb.GetNotesRequest{PageToken: "bad"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Test List Notes Stream", func(t *testing.T) {
+		filter := models.NotesFilter{PageSize: app.MaxPageSize}
+		mockStr.On("GetNotes", ctx, filter).
+			Return(models.NotesPage{Notes: []models.Note{{ID: 1}, {ID: 2}}, NextPageToken: "p2"}, nilError)
+		filter.PageToken = "p2"
+		mockStr.On("GetNotes", ctx, filter).Return(models.NotesPage{Notes: []models.Note{{ID: 3}}}, nilError)
+
+		stream, err := client.ListNotes(ctx, &pb.ListNotesRequest{})
+		require.NoError(t, err)
+
+		var ids []uint64
+		for {
+			n, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			ids = append(ids, n.ID)
+		}
+		assert.Equal(t, []uint64{1, 2, 3}, ids)
+	})
+
+	t.Run("Test Watch Notes", func(t *testing.T) {
+		first := bus.Publish(models.NoteEvent{Type: models.NoteCreated, NoteID: 5, Note: &models.Note{ID: 5}})
+		bus.Publish(models.NoteEvent{Type: models.NoteDeleted, NoteID: 5})
+
+		ctxW, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		stream, err := client.WatchNotes(ctxW, &pb.WatchNotesRequest{ResumeAfter: first.ID})
+		require.NoError(t, err)
+
+		e, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, pb.NoteEventType_NOTE_EVENT_TYPE_DELETED, e.Type)
+		assert.Equal(t, uint64(5), e.NoteID)
+
+		stream, err = client.WatchNotes(ctx, &pb.WatchNotesRequest{ResumeAfter: "unknown-1"})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	t.Run("Test List Tags", func(t *testing.T) {
+		exp := []models.TagCount{{Name: "work", Count: 3}}
+		mockStr.On("ListTags", ctx).Return(exp, nilError)
+
+		res, err := client.ListTags(ctx, &pb.ListTagsRequest{})
+
+		assert.NoError(t, err)
+		require.Len(t, res.GetTags(), 1)
+		assert.Equal(t, "work", res.GetTags()[0].Name)
+		assert.Equal(t, int64(3), res.GetTags()[0].Count)
+	})
+
+	t.Run("Test Search Notes", func(t *testing.T) {
+		exp := models.SearchPage{
+			Results: []models.SearchResult{{Note: models.Note{ID: 3, Title: "buy milk"}, Rank: 0.5, Snippet: "buy <b>milk</b>"}},
+		}
+		mockStr.On("Search", ctx, models.SearchQuery{Query: "milk", Limit: 5}).Return(exp, nilError)
+
+		res, err := client.SearchNotes(ctx, &pb.SearchNotesRequest{Query: " milk ", Limit: 5})
+
+		assert.NoError(t, err)
+		require.Len(t, res.GetResults(), 1)
+		assert.Equal(t, "buy <b>milk</b>", res.GetResults()[0].Snippet)
+		assert.Equal(t, uint64(3), res.GetResults()[0].Note.ID)
+
+		_, err = client.SearchNotes(ctx, &pb.SearchNotesRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
 	t.Run("Test Create Note", func(t *testing.T) {
 		tm, err := time.Parse("02.01.2006 15:04", "14.01.2024 11:03")
 
@@ -87,9 +214,11 @@ func TestBasic(t *testing.T) {
 			Delay:       time.Minute * 20,
 		}
 
-		mockStr.On("CreateNote", ctx, note).Return(nilError)
+		created := note
+		created.ID, created.Version = 21, 1
+		mockStr.On("CreateNote", ctx, note).Return(created, nilError)
 
-		_, err = client.CreateNote(ctx, &pb.CreateNoteRequest{
+		res, err := client.CreateNote(ctx, &pb.CreateNoteRequest{
 			Note: &pb.Note{
 				Title:       "test",
 				Description: "test",
@@ -98,6 +227,7 @@ func TestBasic(t *testing.T) {
 			},
 		})
 		assert.NoError(t, err)
+		assert.Equal(t, uint64(21), res.GetNote().GetID())
 	})
 
 	t.Run("Test Get Note", func(t *testing.T) {
@@ -134,13 +264,22 @@ func TestBasic(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		mockStr.On("DeleteNote", ctx, note.ID).Return(nilError)
+		mockStr.On("DeleteNote", ctx, note.ID, uint64(0)).Return(nilError)
 
 		_, err = client.DeleteNote(ctx, &pb.DeleteNoteRequest{
 			ID: note.ID,
 		})
 
 		assert.NoError(t, err)
+
+		mockStr.On("DeleteNote", ctx, note.ID, uint64(2)).Return(storage.ErrVersionConflict)
+
+		_, err = client.DeleteNote(ctx, &pb.DeleteNoteRequest{
+			ID:              note.ID,
+			ExpectedVersion: 2,
+		})
+
+		assert.Equal(t, codes.Aborted, status.Code(err))
 	})
 
 	t.Run("Test Update Note", func(t *testing.T) {
@@ -155,7 +294,7 @@ func TestBasic(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		mockStr.On("UpdateNote", ctx, note).Return(nilError)
+		mockStr.On("UpdateNote", ctx, note, []models.NoteField{models.FieldTitle, models.FieldDescription, models.FieldDateNotify}).Return(nilError)
 
 		_, err = client.UpdateNote(ctx, &pb.UpdateNoteRequest{
 			Note: grpcserver.ToPBNote(note),
@@ -163,5 +302,320 @@ func TestBasic(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Test Update Note Mask", func(t *testing.T) {
+		ctx := context.Background()
+		note := models.Note{ID: 2, Title: "test"}
+		mockStr.On("UpdateNote", ctx, note,
+			[]models.NoteField{models.FieldTitle, models.FieldDelay}).Return(nilError)
+
+		_, err := client.UpdateNote(ctx, &pb.UpdateNoteRequest{
+			Note:       grpcserver.ToPBNote(note),
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title", "delay"}},
+		})
+		assert.NoError(t, err)
+
+		_, err = client.UpdateNote(ctx, &pb.UpdateNoteRequest{
+			Note:       grpcserver.ToPBNote(note),
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"dateAdded"}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Test Batch Create", func(t *testing.T) {
+		tm := time.Date(2024, 1, 14, 11, 3, 0, 0, time.UTC)
+		patch := monkey.Patch(time.Now, func() time.Time {
+			return tm
+		})
+		defer patch.Unpatch()
+
+		notes := []models.Note{
+			{Title: "a", DateAdded: tm, DateNotify: tm.Add(time.Minute * 20), Delay: time.Minute * 20},
+			{Title: "b", DateAdded: tm, DateNotify: tm.Add(time.Minute * 20), Delay: time.Minute * 20},
+		}
+		mockStr.On("CreateNotes", ctx, notes, models.BatchPartial).Return([]models.BatchResult{
+			{ID: 1, Version: 1},
+			{Err: storage.ErrInvalidField},
+		}, nilError)
+
+		res, err := client.BatchCreate(ctx, &pb.BatchCreateRequest{
+			Notes: []*pb.Note{{Title: "a"}, {Title: "b"}},
+			Mode:  pb.BatchMode_BATCH_MODE_PARTIAL,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.GetResults(), 2)
+		assert.Equal(t, uint64(1), res.GetResults()[0].GetID())
+		assert.Equal(t, int32(codes.InvalidArgument), res.GetResults()[1].GetCode())
+
+		_, err = client.BatchDelete(ctx, &pb.BatchDeleteRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Test Note Actions", func(t *testing.T) {
+		tm := time.Date(2024, 1, 14, 11, 3, 0, 0, time.UTC)
+		patch := monkey.Patch(time.Now, func() time.Time {
+			return tm
+		})
+		defer patch.Unpatch()
+
+		mockStr.On("RefreshNote", ctx, uint64(41), app.MaxRefreshDelay).Return(models.Note{}, true, nilError)
+		mockStr.On("SnoozeNote", ctx, uint64(42), tm.Add(time.Hour)).
+			Return(models.Note{ID: 42, DateNotify: tm.Add(time.Hour), Version: 2}, nilError)
+		mockStr.On("AcknowledgeNote", ctx, uint64(43)).Return(models.Note{ID: 43, AcknowledgedAt: &tm}, nilError)
+
+		refreshed, err := client.RefreshNote(ctx, &pb.RefreshNoteRequest{ID: 41})
+		require.NoError(t, err)
+		assert.True(t, refreshed.GetDeleted())
+		assert.Nil(t, refreshed.GetNote())
+
+		snoozed, err := client.SnoozeNote(ctx, &pb.SnoozeNoteRequest{
+			ID:     42,
+			Snooze: &pb.SnoozeNoteRequest_Duration{Duration: durationpb.New(time.Hour)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, tm.Add(time.Hour), snoozed.GetNote().GetDateNotify().AsTime())
+
+		_, err = client.SnoozeNote(ctx, &pb.SnoozeNoteRequest{
+			ID:     42,
+			Snooze: &pb.SnoozeNoteRequest_Until{Until: timestamppb.New(tm.Add(-time.Hour))},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		acked, err := client.AcknowledgeNote(ctx, &pb.AcknowledgeNoteRequest{ID: 43})
+		require.NoError(t, err)
+		assert.Equal(t, tm, acked.GetNote().GetAcknowledgedAt().AsTime())
+	})
+
+	mockStr.AssertExpectations(t)
+}
+
+func TestAdmin(t *testing.T) {
+	logg, err := logger.New(logger.EnvLocal)
+	require.NoError(t, err)
+
+	users := &userStore{keys: map[string]auth.Principal{}}
+	authenticator := auth.New(config.Auth{Enabled: true, JWTSecret: "secret"}, users)
+	opsKey, err := users.CreateAPIKey(context.Background(), auth.Principal{Name: "ops", Kind: auth.KindService})
+	require.NoError(t, err)
+	publisherKey, err := users.CreateAPIKey(context.Background(),
+		auth.Principal{Name: "publisher", Kind: auth.KindService})
+	require.NoError(t, err)
+
+	// The Admin service is refused without auth, which would leave it open to anyone.
+	_, err = grpcserver.New(app.NewApp(new(storage.MockStorage)), logg, config.GRPCServer{Admin: true})
+	assert.ErrorIs(t, err, grpcserver.ErrAdminWithoutAuth)
+	_, err = grpcserver.New(app.NewApp(new(storage.MockStorage)), logg, config.GRPCServer{Admin: true},
+		notesserver.WithAuthenticator(authenticator))
+	assert.ErrorIs(t, err, grpcserver.ErrNoAdminServices)
+
+	server, err := grpcserver.New(app.NewApp(new(storage.MockStorage)), logg,
+		config.GRPCServer{Admin: true, AdminServices: []string{"ops"}, Reflection: true},
+		notesserver.WithAuthenticator(authenticator),
+		notesserver.WithAdminInfo(notesserver.AdminInfo{
+			Config: config.Config{DB: config.DB{Password: "qwerty", Version: 10}},
+			Logger: logg,
+			MigrationVersion: func(context.Context) (int64, error) {
+				return 9, nil
+			},
+			Users:  users,
+			Tokens: authenticator,
+		}))
+	require.NoError(t, err)
+
+	serveCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lis := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = server.Serve(serveCtx, lis)
+	}()
+	defer server.Shutdown(serveCtx)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := pb.NewAdminClient(conn)
+	_, err = client.GetConfig(context.Background(), &pb.GetConfigRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	// Other services, such as the publisher, may not mint credentials.
+	_, err = client.CreateAPIKey(
+		metadata.AppendToOutgoingContext(context.Background(), strings.ToLower(auth.HeaderAPIKey), publisherKey),
+		&pb.CreateAPIKeyRequest{Principal: &pb.CreateAPIKeyRequest_UserId{UserId: 1}})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), strings.ToLower(auth.HeaderAPIKey), opsKey)
+
+	v, err := client.GetMigrationVersion(ctx, &pb.GetMigrationVersionRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), v.GetApplied())
+	assert.Equal(t, int64(10), v.GetExpected())
+
+	cfg, err := client.GetConfig(ctx, &pb.GetConfigRequest{})
+	require.NoError(t, err)
+	assert.Contains(t, cfg.GetYaml(), "password: REDACTED")
+	assert.NotContains(t, cfg.GetYaml(), "qwerty")
+
+	lvl, err := client.SetLogLevel(ctx, &pb.SetLogLevelRequest{Level: "warn"})
+	require.NoError(t, err)
+	assert.Equal(t, "warn", lvl.GetLevel())
+	assert.Equal(t, zapcore.WarnLevel, logg.Level())
+
+	_, err = client.SetLogLevel(ctx, &pb.SetLogLevelRequest{Level: "loud"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	t.Run("provisioning", func(t *testing.T) {
+		user, err := client.CreateUser(ctx, &pb.CreateUserRequest{Name: "alice"})
+		require.NoError(t, err)
+		assert.Equal(t, "alice", user.GetName())
+
+		key, err := client.CreateAPIKey(ctx, &pb.CreateAPIKeyRequest{
+			Principal: &pb.CreateAPIKeyRequest_UserId{UserId: user.GetId()},
+		})
+		require.NoError(t, err)
+		p, err := authenticator.Authenticate(ctx, auth.Credentials{APIKey: key.GetKey()})
+		require.NoError(t, err)
+		assert.Equal(t, auth.Principal{ID: user.GetId(), Name: "alice", Kind: auth.KindUser}, p)
+
+		token, err := client.IssueUserToken(ctx, &pb.IssueUserTokenRequest{
+			UserId: user.GetId(), Ttl: durationpb.New(time.Hour),
+		})
+		require.NoError(t, err)
+		p, err = authenticator.Authenticate(ctx, auth.Credentials{BearerToken: token.GetToken()})
+		require.NoError(t, err)
+		assert.Equal(t, auth.Principal{ID: user.GetId(), Name: "alice", Kind: auth.KindUser}, p)
+
+		_, err = client.RevokeAPIKey(ctx, &pb.RevokeAPIKeyRequest{Key: key.GetKey()})
+		require.NoError(t, err)
+		_, err = authenticator.Authenticate(ctx, auth.Credentials{APIKey: key.GetKey()})
+		assert.ErrorIs(t, err, auth.ErrInvalidAPIKey)
+		_, err = client.RevokeAPIKey(ctx, &pb.RevokeAPIKeyRequest{Key: key.GetKey()})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		key, err = client.CreateAPIKey(ctx, &pb.CreateAPIKeyRequest{
+			Principal: &pb.CreateAPIKeyRequest_Service{Service: "bot"},
+		})
+		require.NoError(t, err)
+		p, err = authenticator.Authenticate(ctx, auth.Credentials{APIKey: key.GetKey()})
+		require.NoError(t, err)
+		assert.Equal(t, auth.Principal{Name: "bot", Kind: auth.KindService}, p)
+
+		_, err = client.IssueUserToken(ctx, &pb.IssueUserTokenRequest{UserId: 42})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = client.CreateAPIKey(ctx, &pb.CreateAPIKeyRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	refl, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, refl.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	res, err := refl.Recv()
+	require.NoError(t, err)
+	var services []string
+	for _, svc := range res.GetListServicesResponse().GetService() {
+		services = append(services, svc.GetName())
+	}
+	assert.Contains(t, services, pb.Admin_ServiceDesc.ServiceName)
+}
+
+func TestMetrics(t *testing.T) {
+	mockStr := new(storage.MockStorage)
+	logg, err := logger.New(logger.EnvLocal)
+	require.NoError(t, err)
+
+	server, err := grpcserver.New(app.NewApp(mockStr), logg, config.GRPCServer{})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lis := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = server.Serve(ctx, lis)
+	}()
+	defer server.Shutdown(ctx)
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := pb.NewNotesClient(conn)
+
+	mockStr.On("GetNote", context.Background(), uint64(41)).Return(models.Note{ID: 41}, nilError).Once()
+	mockStr.On("GetNote", context.Background(), uint64(42)).Return(models.Note{}, storage.ErrNotFound).Once()
+	_, err = client.GetNote(ctx, &pb.GetNoteRequest{ID: 41})
+	require.NoError(t, err)
+	_, err = client.GetNote(ctx, &pb.GetNoteRequest{ID: 42})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	w := httptest.NewRecorder()
+	promhttp.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	body := w.Body.String()
+	method := pb.Notes_GetNote_FullMethodName
+	assert.Contains(t, body, `notes_grpc_requests_total{code="OK",method="`+method+`"}`)
+	assert.Contains(t, body, `notes_grpc_requests_total{code="NotFound",method="`+method+`"}`)
+	assert.Contains(t, body, `notes_grpc_request_duration_seconds_count{code="OK",method="`+method+`"}`)
 	mockStr.AssertExpectations(t)
 }
+
+// userStore keeps users and API keys in memory.
+type userStore struct {
+	mu    sync.Mutex
+	users []models.User
+	keys  map[string]auth.Principal
+}
+
+func (s *userStore) CreateUser(_ context.Context, name string) (models.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := models.User{ID: uint64(len(s.users) + 1), Name: name, CreatedAt: time.Now()}
+	s.users = append(s.users, u)
+	return u, nil
+}
+
+func (s *userStore) GetUser(_ context.Context, id uint64) (models.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id == 0 || id > uint64(len(s.users)) {
+		return models.User{}, storage.ErrNotFound
+	}
+	return s.users[id-1], nil
+}
+
+func (s *userStore) CreateAPIKey(_ context.Context, p auth.Principal) (string, error) {
+	key, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[hash] = p
+	return key, nil
+}
+
+func (s *userStore) RevokeAPIKey(_ context.Context, keyHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.keys[keyHash]; !ok {
+		return storage.ErrNotFound
+	}
+	delete(s.keys, keyHash)
+	return nil
+}
+
+func (s *userStore) PrincipalByAPIKey(_ context.Context, keyHash string) (auth.Principal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.keys[keyHash]
+	if !ok {
+		return auth.Principal{}, storage.ErrNotFound
+	}
+	return p, nil
+}
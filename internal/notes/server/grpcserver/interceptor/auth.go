@@ -0,0 +1,124 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"notes/internal/pkg/auth"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type StreamServerInterceptor func(srv interface{}, ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error
+
+// publicMethodPrefix marks services that are available without credentials.
+const publicMethodPrefix = "/grpc.health.v1.Health/"
+
+func AuthInterceptor(a *auth.Authenticator) UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		if strings.HasPrefix(info.FullMethod, publicMethodPrefix) {
+			return handler(ctx, req)
+		}
+		ctx, err = authenticate(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func AuthStreamInterceptor(a *auth.Authenticator) StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if strings.HasPrefix(info.FullMethod, publicMethodPrefix) {
+			return handler(srv, ss)
+		}
+		ctx, err := authenticate(ss.Context(), a)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// AllowServicesInterceptor rejects calls of the methods starting with prefix
+// that aren't made by one of the named service principals. It must run after
+// AuthInterceptor.
+func AllowServicesInterceptor(prefix string, names []string) UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		if err := allowService(ctx, prefix, names, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func AllowServicesStreamInterceptor(prefix string, names []string) StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := allowService(ss.Context(), prefix, names, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func allowService(ctx context.Context, prefix string, names []string, method string) error {
+	if !strings.HasPrefix(method, prefix) {
+		return nil
+	}
+	if p, ok := auth.FromContext(ctx); ok && p.IsService() && slices.Contains(names, p.Name) {
+		return nil
+	}
+	return status.Error(codes.PermissionDenied, "not allowed to call "+method)
+}
+
+func authenticate(ctx context.Context, a *auth.Authenticator) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	c := auth.ParseCredentials(
+		first(md.Get(auth.HeaderAuthorization)),
+		first(md.Get(auth.HeaderAPIKey)),
+	)
+
+	p, err := a.Authenticate(ctx, c)
+	if err != nil {
+		if errors.Is(err, auth.ErrUnauthenticated) {
+			return nil, status.Error(codes.Unauthenticated, "missing credentials")
+		}
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return auth.NewContext(ctx, p), nil
+}
+
+// serverStream overrides the context of a wrapped stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func first(v []string) string {
+	if len(v) == 0 {
+		return ""
+	}
+	return v[0]
+}
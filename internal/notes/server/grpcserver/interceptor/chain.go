@@ -0,0 +1,87 @@
+package interceptor
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+)
+
+// Names of the interceptors in the configurable chain order.
+const (
+	Recovery    = "recovery"
+	RequestID   = "requestID"
+	Tracing     = "tracing"
+	Auth        = "auth"
+	RateLimit   = "rateLimit"
+	Logging     = "logging"
+	Metrics     = "metrics"
+	Idempotency = "idempotency"
+)
+
+// DefaultOrder is the order of the chain when none is configured. Idempotency
+// comes after auth because keys are scoped by caller.
+var DefaultOrder = []string{Recovery, RequestID, Tracing, Auth, RateLimit, Logging, Metrics, Idempotency}
+
+var (
+	ErrUnknownInterceptor = errors.New("unknown interceptor")
+	ErrInterceptorOrder   = errors.New("invalid interceptor order")
+)
+
+// Chain collects the unary and stream interceptors of the server by name, so
+// that they can be installed in a configured order.
+type Chain struct {
+	unary  map[string][]grpc.UnaryServerInterceptor
+	stream map[string][]grpc.StreamServerInterceptor
+}
+
+func NewChain() *Chain {
+	return &Chain{
+		unary:  map[string][]grpc.UnaryServerInterceptor{},
+		stream: map[string][]grpc.StreamServerInterceptor{},
+	}
+}
+
+// Add appends interceptors to name, either of them may be nil. Interceptors
+// added under the same name run in the order they were added.
+func (c *Chain) Add(name string, u UnaryServerInterceptor, s StreamServerInterceptor) {
+	if u != nil {
+		c.unary[name] = append(c.unary[name], grpc.UnaryServerInterceptor(u))
+	}
+	if s != nil {
+		c.stream[name] = append(c.stream[name], grpc.StreamServerInterceptor(s))
+	}
+}
+
+// Build returns the interceptors in order, the first one is the outermost. The
+// order must name every added interceptor once, so that none is left out by mistake.
+func (c *Chain) Build(order []string) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor, error) {
+	known := make(map[string]bool, len(DefaultOrder))
+	for _, name := range DefaultOrder {
+		known[name] = true
+	}
+
+	var (
+		unary  []grpc.UnaryServerInterceptor
+		stream []grpc.StreamServerInterceptor
+		seen   = make(map[string]bool, len(order))
+	)
+	for _, name := range order {
+		switch {
+		case !known[name]:
+			return nil, nil, fmt.Errorf("%w %q", ErrUnknownInterceptor, name)
+		case seen[name]:
+			return nil, nil, fmt.Errorf("%w: %q is listed twice", ErrInterceptorOrder, name)
+		}
+		seen[name] = true
+		unary = append(unary, c.unary[name]...)
+		stream = append(stream, c.stream[name]...)
+	}
+
+	for _, name := range DefaultOrder {
+		if !seen[name] && (len(c.unary[name]) > 0 || len(c.stream[name]) > 0) {
+			return nil, nil, fmt.Errorf("%w: %q is missing", ErrInterceptorOrder, name)
+		}
+	}
+	return unary, stream, nil
+}
@@ -0,0 +1,142 @@
+package interceptor_test
+
+import (
+	"context"
+	"net"
+	"notes/internal/notes/app"
+	"notes/internal/notes/ratelimit"
+	"notes/internal/notes/server/grpcserver"
+	"notes/internal/notes/server/grpcserver/interceptor"
+	"notes/internal/notes/server/grpcserver/pb"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/config"
+	"notes/internal/pkg/logger"
+	"notes/internal/pkg/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestChainBuild(t *testing.T) {
+	logg, err := logger.New(logger.EnvLocal)
+	require.NoError(t, err)
+
+	chain := interceptor.NewChain()
+	chain.Add(interceptor.Recovery, interceptor.RecoveryInterceptor(logg), interceptor.RecoveryStreamInterceptor(logg))
+	chain.Add(interceptor.Logging, interceptor.LoggingInterceptor(logg), nil)
+
+	unary, stream, err := chain.Build(interceptor.DefaultOrder)
+	require.NoError(t, err)
+	assert.Len(t, unary, 2)
+	assert.Len(t, stream, 1)
+
+	_, _, err = chain.Build([]string{interceptor.Recovery, interceptor.Logging, "cache"})
+	assert.ErrorIs(t, err, interceptor.ErrUnknownInterceptor)
+
+	_, _, err = chain.Build([]string{interceptor.Recovery, interceptor.Logging, interceptor.Recovery})
+	assert.ErrorIs(t, err, interceptor.ErrInterceptorOrder)
+
+	// Auth isn't added, so it may be left out, but logging may not.
+	_, _, err = chain.Build([]string{interceptor.Recovery, interceptor.Metrics})
+	assert.ErrorIs(t, err, interceptor.ErrInterceptorOrder)
+
+	// A misconfigured server fails to start rather than using another order.
+	_, err = grpcserver.New(app.NewApp(new(storage.MockStorage)), logg,
+		config.GRPCServer{Interceptors: []string{interceptor.Recovery, "cache"}})
+	assert.ErrorIs(t, err, interceptor.ErrUnknownInterceptor)
+}
+
+func TestRecovery(t *testing.T) {
+	mockStr := new(storage.MockStorage)
+	logg, err := logger.New(logger.EnvLocal)
+	require.NoError(t, err)
+	core, logs := observer.New(zap.ErrorLevel)
+	panics := logger.Logger{SugaredLogger: zap.New(core).Sugar()}
+
+	chain := interceptor.NewChain()
+	chain.Add(interceptor.Recovery, interceptor.RecoveryInterceptor(panics), interceptor.RecoveryStreamInterceptor(panics))
+	chain.Add(interceptor.RequestID, interceptor.RequestIDInterceptor(), interceptor.RequestIDStreamInterceptor())
+	chain.Add(interceptor.Logging, interceptor.LoggingInterceptor(logg), interceptor.LoggingStreamInterceptor(logg))
+	unary, stream, err := chain.Build(interceptor.DefaultOrder)
+	require.NoError(t, err)
+
+	server, err := grpcserver.New(app.NewApp(mockStr), logg, config.GRPCServer{})
+	require.NoError(t, err)
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...))
+	pb.RegisterNotesServer(s, server)
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	defer s.Stop()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := pb.NewNotesClient(conn)
+	ctx := context.Background()
+
+	mockStr.On("GetNote", ctx, uint64(1)).Run(func(mock.Arguments) {
+		panic("boom")
+	}).Return(models.Note{}, nil)
+	mockStr.On("GetNote", ctx, uint64(2)).Return(models.Note{ID: 2}, nil)
+	mockStr.On("GetNotes", ctx, models.NotesFilter{PageSize: app.MaxPageSize}).Run(func(mock.Arguments) {
+		panic("boom")
+	}).Return(models.NotesPage{}, nil)
+
+	var header metadata.MD
+	_, err = client.GetNote(ctx, &pb.GetNoteRequest{ID: 1}, grpc.Header(&header))
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Len(t, header.Get(interceptor.RequestIDMD), 1)
+
+	st, err := client.ListNotes(metadata.AppendToOutgoingContext(ctx, interceptor.RequestIDMD, "req-0"),
+		&pb.ListNotesRequest{})
+	require.NoError(t, err)
+	_, err = st.Recv()
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// The panics are logged with the request IDs set after the recovery.
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	assert.Contains(t, entries[0].Message, "Request ID "+header.Get(interceptor.RequestIDMD)[0]+"\t")
+	assert.Contains(t, entries[1].Message, "Request ID req-0\t")
+
+	// The server keeps serving and echoes the request ID of the client.
+	res, err := client.GetNote(metadata.AppendToOutgoingContext(ctx, interceptor.RequestIDMD, "req-1"),
+		&pb.GetNoteRequest{ID: 2}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), res.GetNote().GetID())
+	assert.Equal(t, []string{"req-1"}, header.Get(interceptor.RequestIDMD))
+}
+
+func TestRateLimit(t *testing.T) {
+	limiter := ratelimit.New(config.RateLimit{Default: config.Limit{Rate: 0.001, Burst: 1}})
+	limit := interceptor.RateLimitInterceptor(limiter, nil)
+	info := &grpc.UnaryServerInfo{FullMethod: pb.Notes_CreateNote_FullMethodName}
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	resp, err := limit(context.Background(), nil, info, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = limit(context.Background(), nil, info, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -0,0 +1,106 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"notes/internal/notes/idempotency"
+	"notes/internal/pkg/logger"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var (
+	idempotencyKeyMD      = strings.ToLower(idempotency.HeaderKey)
+	idempotencyReplayedMD = strings.ToLower(idempotency.HeaderReplayed)
+)
+
+// IdempotencyInterceptor executes calls of the given methods with an idempotency-key
+// metadata entry once. Retries get the stored response of a successful call, a key
+// reused for another request fails with InvalidArgument and one whose call is still
+// running with Aborted. Failed calls are not stored, so they can be retried. Keys of
+// unauthenticated callers are scoped by their IP, which only the proxies may forward.
+func IdempotencyInterceptor(k *idempotency.Keys, logg logger.Logger, proxies []netip.Prefix,
+	methods ...string,
+) UnaryServerInterceptor {
+	mutating := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		mutating[m] = true
+	}
+
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		key := first(md.Get(idempotencyKeyMD))
+		msg, ok := req.(proto.Message)
+		if key == "" || !ok || !mutating[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		b, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		r, replay, err := k.Begin(ctx, key, clientHost(ctx, proxies), idempotency.Fingerprint([]byte(info.FullMethod), b))
+		switch {
+		case errors.Is(err, idempotency.ErrInvalidKey), errors.Is(err, idempotency.ErrKeyMismatch):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, idempotency.ErrInProgress):
+			return nil, status.Error(codes.Aborted, err.Error())
+		case err != nil:
+			return nil, status.Error(codes.Internal, err.Error())
+		case replay:
+			var a anypb.Any
+			if err := proto.Unmarshal(r.Body, &a); err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			resp, err := a.UnmarshalNew()
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			_ = grpc.SetHeader(ctx, metadata.Pairs(idempotencyReplayedMD, "true"))
+			return resp, nil
+		}
+
+		resp, err = handler(ctx, req)
+
+		// The result is kept even when the client has gone away.
+		saveCtx := context.WithoutCancel(ctx)
+		if err == nil {
+			ferr := finish(saveCtx, k, r, resp)
+			if ferr == nil {
+				return resp, nil
+			}
+			logg.Errorf("storing idempotency key %q failed: %s", key, ferr)
+		}
+		// A key left without a result would block retries until it expires.
+		if err := k.Abandon(saveCtx, r); err != nil {
+			logg.Errorf("releasing idempotency key %q failed: %s", key, err)
+		}
+		return resp, err
+	}
+}
+
+func finish(ctx context.Context, k *idempotency.Keys, r idempotency.Record, resp interface{}) error {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return fmt.Errorf("unexpected response %T", resp)
+	}
+	a, err := anypb.New(msg)
+	if err != nil {
+		return err
+	}
+	if r.Body, err = proto.Marshal(a); err != nil {
+		return err
+	}
+	return k.Finish(ctx, r)
+}
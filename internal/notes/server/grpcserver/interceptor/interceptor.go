@@ -3,14 +3,38 @@ package interceptor
 import (
 	"context"
 	"notes/internal/pkg/logger"
+	"notes/internal/pkg/tracing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "notes",
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Number of gRPC requests by method and status code.",
+	}, []string{"method", "code"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "notes",
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of gRPC requests by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+)
+
 type UnaryServerInterceptor func(ctx context.Context, req interface{},
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler) (resp interface{}, err error)
@@ -20,39 +44,53 @@ func LoggingInterceptor(logg logger.Logger) UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (resp interface{}, err error) {
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			logg.Error("cannot get metadata from context")
-			return handler(ctx, req)
-		}
-		userAgent := getUsegAgents(md)
-		clientIP := getIP(ctx)
+		start := time.Now()
+		resp, err = handler(ctx, req)
+		logCall(ctx, logg, "GRPC API request", info.FullMethod, start, err)
+		return resp, err
+	}
+}
 
+func LoggingStreamInterceptor(logg logger.Logger) StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
 		start := time.Now()
+		err := handler(srv, ss)
+		logCall(ss.Context(), logg, "GRPC API stream", info.FullMethod, start, err)
+		return err
+	}
+}
 
-		method := info.FullMethod
+func logCall(ctx context.Context, logg logger.Logger, kind, method string, start time.Time, err error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logg.Error("cannot get metadata from context")
+		return
+	}
+	userAgent := getUsegAgents(md)
+	clientIP := getIP(ctx)
 
-		resp, err = handler(ctx, req)
+	latency := time.Since(start)
 
-		latency := time.Since(start)
-
-		var statusCode string
-		var message string
-		if stat, ok := status.FromError(err); ok {
-			statusCode = stat.Code().String()
-			message = stat.Message()
-		}
-
-		logg.Infof("GRPC API request	METHOD %s	STATUS %s	Latency %s	Message %s	Client IP %s	User Agent %s\n",
-			method,
-			statusCode,
-			latency.String(),
-			message,
-			clientIP,
-			userAgent,
-		)
-		return resp, err
+	var statusCode string
+	var message string
+	if stat, ok := status.FromError(err); ok {
+		statusCode = stat.Code().String()
+		message = stat.Message()
 	}
+
+	logg.Infof("%s	METHOD %s	STATUS %s	Latency %s	Message %s	Client IP %s	User Agent %s	Request ID %s\n",
+		kind,
+		method,
+		statusCode,
+		latency.String(),
+		message,
+		clientIP,
+		userAgent,
+		RequestIDFromContext(ctx),
+	)
 }
 
 func getUsegAgents(md metadata.MD) string {
@@ -74,3 +112,78 @@ func getIP(ctx context.Context) string {
 	addr := pr.Addr.String()
 	return addr
 }
+
+var tracer = otel.Tracer("notes/internal/notes/server/grpcserver")
+
+func TracingInterceptor() UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		ctx, span := startSpan(ctx, info.FullMethod)
+		defer span.End()
+
+		resp, err = handler(ctx, req)
+		tracing.RecordGRPCStatus(span, err)
+		return resp, err
+	}
+}
+
+func TracingStreamInterceptor() StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, span := startSpan(ss.Context(), info.FullMethod)
+		defer span.End()
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		tracing.RecordGRPCStatus(span, err)
+		return err
+	}
+}
+
+func startSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	return tracer.Start(tracing.ExtractIncoming(ctx), method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			attribute.String("rpc.method", method),
+			attribute.String("net.peer.addr", getIP(ctx)),
+		),
+	)
+}
+
+func MetricsInterceptor() UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		start := time.Now()
+
+		resp, err = handler(ctx, req)
+
+		observe(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func MetricsStreamInterceptor() StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		observe(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func observe(method string, start time.Time, err error) {
+	code := status.Code(err).String()
+	requestsTotal.WithLabelValues(method, code).Inc()
+	requestDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
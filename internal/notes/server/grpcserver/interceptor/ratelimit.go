@@ -0,0 +1,103 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"notes/internal/notes/ratelimit"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ClientIPMD carries the IP address of the client of the gateway and Connect
+// proxies, the gRPC server only sees the address of the proxy.
+const ClientIPMD = "x-client-ip"
+
+// retryAfterMD tells a rate limited client how many seconds to wait, like the REST Retry-After header.
+const retryAfterMD = "retry-after"
+
+// RateLimitInterceptor fails calls with ResourceExhausted when the caller has
+// run out of requests on the method. Unauthenticated callers are keyed by their
+// IP address, the one in ClientIPMD for calls from the proxies. It must run after
+// AuthInterceptor.
+func RateLimitInterceptor(l *ratelimit.Limiter, proxies []netip.Prefix) UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		if err := allow(ctx, l, proxies, info.FullMethod, func(md metadata.MD) error {
+			return grpc.SetHeader(ctx, md)
+		}); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func RateLimitStreamInterceptor(l *ratelimit.Limiter, proxies []netip.Prefix) StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := allow(ss.Context(), l, proxies, info.FullMethod, ss.SetHeader); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func allow(ctx context.Context, l *ratelimit.Limiter, proxies []netip.Prefix, method string,
+	setHeader func(metadata.MD) error,
+) error {
+	ok, wait := l.Allow(method, ratelimit.Caller(ctx, clientHost(ctx, proxies)))
+	if ok {
+		return nil
+	}
+	_ = setHeader(metadata.Pairs(retryAfterMD, ratelimit.RetryAfter(wait)))
+	return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry in %s", wait)
+}
+
+// clientHost is the IP address of the client without its port. Only calls from
+// the proxies may set it in ClientIPMD, other callers could spoof it.
+func clientHost(ctx context.Context, proxies []netip.Prefix) string {
+	host := getIP(ctx)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return host
+	}
+	for _, p := range proxies {
+		if !p.Contains(addr.Unmap()) {
+			continue
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		if ip := first(md.Get(ClientIPMD)); ip != "" {
+			return ip
+		}
+		break
+	}
+	return host
+}
+
+// ParseProxies parses the addresses or CIDRs of trusted proxies.
+func ParseProxies(proxies []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(proxies))
+	for _, s := range proxies {
+		if addr, err := netip.ParseAddr(s); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("bad trusted proxy %q: %w", s, err)
+		}
+		prefixes = append(prefixes, p.Masked())
+	}
+	return prefixes, nil
+}
@@ -0,0 +1,59 @@
+package interceptor
+
+import (
+	"context"
+	"notes/internal/pkg/logger"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveryInterceptor turns a panic in the rest of the chain into an Internal
+// error and logs it with the stack trace, instead of crashing the server.
+func RecoveryInterceptor(logg logger.Logger) UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		ctx, id := withRequestIDSlot(ctx)
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, *id, logg, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func RecoveryStreamInterceptor(logg logger.Logger) StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		ctx, id := withRequestIDSlot(ss.Context())
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, *id, logg, info.FullMethod, r)
+			}
+		}()
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// recovered logs the panic r with the request ID, which the request ID
+// interceptor sets in id when it runs after the recovery one.
+func recovered(ctx context.Context, id string, logg logger.Logger, method string, r interface{}) error {
+	if id == "" {
+		id = RequestIDFromContext(ctx)
+	}
+	if id == "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		id = first(md.Get(RequestIDMD))
+	}
+	logg.Errorf("GRPC API panic	METHOD %s	Request ID %s	Panic %v\n%s",
+		method, id, r, debug.Stack())
+	return status.Error(codes.Internal, "internal error")
+}
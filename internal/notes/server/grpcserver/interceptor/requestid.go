@@ -0,0 +1,80 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// HeaderRequestID is the HTTP header the gateways forward as RequestIDMD.
+	HeaderRequestID = "X-Request-Id"
+	// RequestIDMD is the metadata key of the request ID, it is sent back in the response header.
+	RequestIDMD = "x-request-id"
+)
+
+// maxRequestIDLength bounds the IDs accepted from clients, longer ones are replaced.
+const maxRequestIDLength = 128
+
+type (
+	requestIDKey     struct{}
+	requestIDSlotKey struct{}
+)
+
+// RequestIDInterceptor keeps the request ID sent by the client or generates
+// one, stores it in the context and returns it in the response header.
+func RequestIDInterceptor() UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		ctx, id := withRequestID(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMD, id))
+		return handler(ctx, req)
+	}
+}
+
+func RequestIDStreamInterceptor() StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, id := withRequestID(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(RequestIDMD, id))
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// RequestIDFromContext returns the request ID of the call, if any.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+func withRequestID(ctx context.Context) (context.Context, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := first(md.Get(RequestIDMD))
+	if id == "" || len(id) > maxRequestIDLength {
+		id = newRequestID()
+	}
+	if slot, ok := ctx.Value(requestIDSlotKey{}).(*string); ok {
+		*slot = id
+	}
+	return context.WithValue(ctx, requestIDKey{}, id), id
+}
+
+// withRequestIDSlot returns ctx with a slot that withRequestID fills, for the
+// interceptors that run before it and still need the request ID.
+func withRequestIDSlot(ctx context.Context) (context.Context, *string) {
+	slot := new(string)
+	return context.WithValue(ctx, requestIDSlotKey{}, slot), slot
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.3.0
-// - protoc             v3.6.1
+// - protoc             (unknown)
 // source: api/notes.proto
 
 package pb
@@ -19,11 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Notes_GetNotes_FullMethodName   = "/gprc_notes.Notes/GetNotes"
-	Notes_GetNote_FullMethodName    = "/gprc_notes.Notes/GetNote"
-	Notes_CreateNote_FullMethodName = "/gprc_notes.Notes/CreateNote"
-	Notes_DeleteNote_FullMethodName = "/gprc_notes.Notes/DeleteNote"
-	Notes_UpdateNote_FullMethodName = "/gprc_notes.Notes/UpdateNote"
+	Notes_GetNotes_FullMethodName        = "/gprc_notes.Notes/GetNotes"
+	Notes_GetNote_FullMethodName         = "/gprc_notes.Notes/GetNote"
+	Notes_CreateNote_FullMethodName      = "/gprc_notes.Notes/CreateNote"
+	Notes_DeleteNote_FullMethodName      = "/gprc_notes.Notes/DeleteNote"
+	Notes_UpdateNote_FullMethodName      = "/gprc_notes.Notes/UpdateNote"
+	Notes_ListTags_FullMethodName        = "/gprc_notes.Notes/ListTags"
+	Notes_SearchNotes_FullMethodName     = "/gprc_notes.Notes/SearchNotes"
+	Notes_ListNotes_FullMethodName       = "/gprc_notes.Notes/ListNotes"
+	Notes_WatchNotes_FullMethodName      = "/gprc_notes.Notes/WatchNotes"
+	Notes_BatchCreate_FullMethodName     = "/gprc_notes.Notes/BatchCreate"
+	Notes_BatchUpdate_FullMethodName     = "/gprc_notes.Notes/BatchUpdate"
+	Notes_BatchDelete_FullMethodName     = "/gprc_notes.Notes/BatchDelete"
+	Notes_RefreshNote_FullMethodName     = "/gprc_notes.Notes/RefreshNote"
+	Notes_SnoozeNote_FullMethodName      = "/gprc_notes.Notes/SnoozeNote"
+	Notes_AcknowledgeNote_FullMethodName = "/gprc_notes.Notes/AcknowledgeNote"
 )
 
 // NotesClient is the client API for Notes service.
@@ -34,7 +44,25 @@ type NotesClient interface {
 	GetNote(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*GetNoteResponse, error)
 	CreateNote(ctx context.Context, in *CreateNoteRequest, opts ...grpc.CallOption) (*CreateNoteResponse, error)
 	DeleteNote(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*DeleteNoteResponse, error)
+	// UpdateNote sets the fields present in the body when served over REST.
 	UpdateNote(ctx context.Context, in *UpdateNoteRequest, opts ...grpc.CallOption) (*UpdateNoteResponse, error)
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	SearchNotes(ctx context.Context, in *SearchNotesRequest, opts ...grpc.CallOption) (*SearchNotesResponse, error)
+	// ListNotes streams every matching note instead of returning pages.
+	ListNotes(ctx context.Context, in *ListNotesRequest, opts ...grpc.CallOption) (Notes_ListNotesClient, error)
+	// WatchNotes streams note changes as they happen.
+	WatchNotes(ctx context.Context, in *WatchNotesRequest, opts ...grpc.CallOption) (Notes_WatchNotesClient, error)
+	// BatchCreate, BatchUpdate and BatchDelete change up to 1000 notes in one transaction.
+	BatchCreate(ctx context.Context, in *BatchCreateRequest, opts ...grpc.CallOption) (*BatchCreateResponse, error)
+	BatchUpdate(ctx context.Context, in *BatchUpdateRequest, opts ...grpc.CallOption) (*BatchUpdateResponse, error)
+	BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error)
+	// RefreshNote reschedules a due note after its delay and multiplies the delay by ten.
+	// Notes whose delay would exceed a year are deleted instead.
+	RefreshNote(ctx context.Context, in *RefreshNoteRequest, opts ...grpc.CallOption) (*RefreshNoteResponse, error)
+	// SnoozeNote postpones the reminder of a note and clears its acknowledgement.
+	SnoozeNote(ctx context.Context, in *SnoozeNoteRequest, opts ...grpc.CallOption) (*SnoozeNoteResponse, error)
+	// AcknowledgeNote stops the reminders of a note until it is snoozed or rescheduled.
+	AcknowledgeNote(ctx context.Context, in *AcknowledgeNoteRequest, opts ...grpc.CallOption) (*AcknowledgeNoteResponse, error)
 }
 
 type notesClient struct {
@@ -90,6 +118,142 @@ func (c *notesClient) UpdateNote(ctx context.Context, in *UpdateNoteRequest, opt
 	return out, nil
 }
 
+func (c *notesClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, Notes_ListTags_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesClient) SearchNotes(ctx context.Context, in *SearchNotesRequest, opts ...grpc.CallOption) (*SearchNotesResponse, error) {
+	out := new(SearchNotesResponse)
+	err := c.cc.Invoke(ctx, Notes_SearchNotes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesClient) ListNotes(ctx context.Context, in *ListNotesRequest, opts ...grpc.CallOption) (Notes_ListNotesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Notes_ServiceDesc.Streams[0], Notes_ListNotes_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &notesListNotesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Notes_ListNotesClient interface {
+	Recv() (*Note, error)
+	grpc.ClientStream
+}
+
+type notesListNotesClient struct {
+	grpc.ClientStream
+}
+
+func (x *notesListNotesClient) Recv() (*Note, error) {
+	m := new(Note)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *notesClient) WatchNotes(ctx context.Context, in *WatchNotesRequest, opts ...grpc.CallOption) (Notes_WatchNotesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Notes_ServiceDesc.Streams[1], Notes_WatchNotes_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &notesWatchNotesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Notes_WatchNotesClient interface {
+	Recv() (*NoteEvent, error)
+	grpc.ClientStream
+}
+
+type notesWatchNotesClient struct {
+	grpc.ClientStream
+}
+
+func (x *notesWatchNotesClient) Recv() (*NoteEvent, error) {
+	m := new(NoteEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *notesClient) BatchCreate(ctx context.Context, in *BatchCreateRequest, opts ...grpc.CallOption) (*BatchCreateResponse, error) {
+	out := new(BatchCreateResponse)
+	err := c.cc.Invoke(ctx, Notes_BatchCreate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesClient) BatchUpdate(ctx context.Context, in *BatchUpdateRequest, opts ...grpc.CallOption) (*BatchUpdateResponse, error) {
+	out := new(BatchUpdateResponse)
+	err := c.cc.Invoke(ctx, Notes_BatchUpdate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesClient) BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error) {
+	out := new(BatchDeleteResponse)
+	err := c.cc.Invoke(ctx, Notes_BatchDelete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesClient) RefreshNote(ctx context.Context, in *RefreshNoteRequest, opts ...grpc.CallOption) (*RefreshNoteResponse, error) {
+	out := new(RefreshNoteResponse)
+	err := c.cc.Invoke(ctx, Notes_RefreshNote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesClient) SnoozeNote(ctx context.Context, in *SnoozeNoteRequest, opts ...grpc.CallOption) (*SnoozeNoteResponse, error) {
+	out := new(SnoozeNoteResponse)
+	err := c.cc.Invoke(ctx, Notes_SnoozeNote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notesClient) AcknowledgeNote(ctx context.Context, in *AcknowledgeNoteRequest, opts ...grpc.CallOption) (*AcknowledgeNoteResponse, error) {
+	out := new(AcknowledgeNoteResponse)
+	err := c.cc.Invoke(ctx, Notes_AcknowledgeNote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NotesServer is the server API for Notes service.
 // All implementations must embed UnimplementedNotesServer
 // for forward compatibility
@@ -98,7 +262,25 @@ type NotesServer interface {
 	GetNote(context.Context, *GetNoteRequest) (*GetNoteResponse, error)
 	CreateNote(context.Context, *CreateNoteRequest) (*CreateNoteResponse, error)
 	DeleteNote(context.Context, *DeleteNoteRequest) (*DeleteNoteResponse, error)
+	// UpdateNote sets the fields present in the body when served over REST.
 	UpdateNote(context.Context, *UpdateNoteRequest) (*UpdateNoteResponse, error)
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	SearchNotes(context.Context, *SearchNotesRequest) (*SearchNotesResponse, error)
+	// ListNotes streams every matching note instead of returning pages.
+	ListNotes(*ListNotesRequest, Notes_ListNotesServer) error
+	// WatchNotes streams note changes as they happen.
+	WatchNotes(*WatchNotesRequest, Notes_WatchNotesServer) error
+	// BatchCreate, BatchUpdate and BatchDelete change up to 1000 notes in one transaction.
+	BatchCreate(context.Context, *BatchCreateRequest) (*BatchCreateResponse, error)
+	BatchUpdate(context.Context, *BatchUpdateRequest) (*BatchUpdateResponse, error)
+	BatchDelete(context.Context, *BatchDeleteRequest) (*BatchDeleteResponse, error)
+	// RefreshNote reschedules a due note after its delay and multiplies the delay by ten.
+	// Notes whose delay would exceed a year are deleted instead.
+	RefreshNote(context.Context, *RefreshNoteRequest) (*RefreshNoteResponse, error)
+	// SnoozeNote postpones the reminder of a note and clears its acknowledgement.
+	SnoozeNote(context.Context, *SnoozeNoteRequest) (*SnoozeNoteResponse, error)
+	// AcknowledgeNote stops the reminders of a note until it is snoozed or rescheduled.
+	AcknowledgeNote(context.Context, *AcknowledgeNoteRequest) (*AcknowledgeNoteResponse, error)
 	mustEmbedUnimplementedNotesServer()
 }
 
@@ -121,6 +303,36 @@ func (UnimplementedNotesServer) DeleteNote(context.Context, *DeleteNoteRequest)
 func (UnimplementedNotesServer) UpdateNote(context.Context, *UpdateNoteRequest) (*UpdateNoteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateNote not implemented")
 }
+func (UnimplementedNotesServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedNotesServer) SearchNotes(context.Context, *SearchNotesRequest) (*SearchNotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchNotes not implemented")
+}
+func (UnimplementedNotesServer) ListNotes(*ListNotesRequest, Notes_ListNotesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListNotes not implemented")
+}
+func (UnimplementedNotesServer) WatchNotes(*WatchNotesRequest, Notes_WatchNotesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNotes not implemented")
+}
+func (UnimplementedNotesServer) BatchCreate(context.Context, *BatchCreateRequest) (*BatchCreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreate not implemented")
+}
+func (UnimplementedNotesServer) BatchUpdate(context.Context, *BatchUpdateRequest) (*BatchUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdate not implemented")
+}
+func (UnimplementedNotesServer) BatchDelete(context.Context, *BatchDeleteRequest) (*BatchDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDelete not implemented")
+}
+func (UnimplementedNotesServer) RefreshNote(context.Context, *RefreshNoteRequest) (*RefreshNoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshNote not implemented")
+}
+func (UnimplementedNotesServer) SnoozeNote(context.Context, *SnoozeNoteRequest) (*SnoozeNoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnoozeNote not implemented")
+}
+func (UnimplementedNotesServer) AcknowledgeNote(context.Context, *AcknowledgeNoteRequest) (*AcknowledgeNoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcknowledgeNote not implemented")
+}
 func (UnimplementedNotesServer) mustEmbedUnimplementedNotesServer() {}
 
 // UnsafeNotesServer may be embedded to opt out of forward compatibility for this service.
@@ -224,6 +436,192 @@ func _Notes_UpdateNote_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Notes_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notes_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notes_SearchNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServer).SearchNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notes_SearchNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServer).SearchNotes(ctx, req.(*SearchNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notes_ListNotes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListNotesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NotesServer).ListNotes(m, &notesListNotesServer{stream})
+}
+
+type Notes_ListNotesServer interface {
+	Send(*Note) error
+	grpc.ServerStream
+}
+
+type notesListNotesServer struct {
+	grpc.ServerStream
+}
+
+func (x *notesListNotesServer) Send(m *Note) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Notes_WatchNotes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchNotesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NotesServer).WatchNotes(m, &notesWatchNotesServer{stream})
+}
+
+type Notes_WatchNotesServer interface {
+	Send(*NoteEvent) error
+	grpc.ServerStream
+}
+
+type notesWatchNotesServer struct {
+	grpc.ServerStream
+}
+
+func (x *notesWatchNotesServer) Send(m *NoteEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Notes_BatchCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServer).BatchCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notes_BatchCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServer).BatchCreate(ctx, req.(*BatchCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notes_BatchUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServer).BatchUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notes_BatchUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServer).BatchUpdate(ctx, req.(*BatchUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notes_BatchDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServer).BatchDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notes_BatchDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServer).BatchDelete(ctx, req.(*BatchDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notes_RefreshNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServer).RefreshNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notes_RefreshNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServer).RefreshNote(ctx, req.(*RefreshNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notes_SnoozeNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnoozeNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServer).SnoozeNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notes_SnoozeNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServer).SnoozeNote(ctx, req.(*SnoozeNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notes_AcknowledgeNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotesServer).AcknowledgeNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notes_AcknowledgeNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotesServer).AcknowledgeNote(ctx, req.(*AcknowledgeNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Notes_ServiceDesc is the grpc.ServiceDesc for Notes service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -251,7 +649,50 @@ var Notes_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateNote",
 			Handler:    _Notes_UpdateNote_Handler,
 		},
+		{
+			MethodName: "ListTags",
+			Handler:    _Notes_ListTags_Handler,
+		},
+		{
+			MethodName: "SearchNotes",
+			Handler:    _Notes_SearchNotes_Handler,
+		},
+		{
+			MethodName: "BatchCreate",
+			Handler:    _Notes_BatchCreate_Handler,
+		},
+		{
+			MethodName: "BatchUpdate",
+			Handler:    _Notes_BatchUpdate_Handler,
+		},
+		{
+			MethodName: "BatchDelete",
+			Handler:    _Notes_BatchDelete_Handler,
+		},
+		{
+			MethodName: "RefreshNote",
+			Handler:    _Notes_RefreshNote_Handler,
+		},
+		{
+			MethodName: "SnoozeNote",
+			Handler:    _Notes_SnoozeNote_Handler,
+		},
+		{
+			MethodName: "AcknowledgeNote",
+			Handler:    _Notes_AcknowledgeNote_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListNotes",
+			Handler:       _Notes_ListNotes_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchNotes",
+			Handler:       _Notes_WatchNotes_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/notes.proto",
 }
@@ -0,0 +1,516 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: api/notes.proto
+
+package pbconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	http "net/http"
+	pb "notes/internal/notes/server/grpcserver/pb"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// NotesName is the fully-qualified name of the Notes service.
+	NotesName = "gprc_notes.Notes"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// NotesGetNotesProcedure is the fully-qualified name of the Notes's GetNotes RPC.
+	NotesGetNotesProcedure = "/gprc_notes.Notes/GetNotes"
+	// NotesGetNoteProcedure is the fully-qualified name of the Notes's GetNote RPC.
+	NotesGetNoteProcedure = "/gprc_notes.Notes/GetNote"
+	// NotesCreateNoteProcedure is the fully-qualified name of the Notes's CreateNote RPC.
+	NotesCreateNoteProcedure = "/gprc_notes.Notes/CreateNote"
+	// NotesDeleteNoteProcedure is the fully-qualified name of the Notes's DeleteNote RPC.
+	NotesDeleteNoteProcedure = "/gprc_notes.Notes/DeleteNote"
+	// NotesUpdateNoteProcedure is the fully-qualified name of the Notes's UpdateNote RPC.
+	NotesUpdateNoteProcedure = "/gprc_notes.Notes/UpdateNote"
+	// NotesListTagsProcedure is the fully-qualified name of the Notes's ListTags RPC.
+	NotesListTagsProcedure = "/gprc_notes.Notes/ListTags"
+	// NotesSearchNotesProcedure is the fully-qualified name of the Notes's SearchNotes RPC.
+	NotesSearchNotesProcedure = "/gprc_notes.Notes/SearchNotes"
+	// NotesListNotesProcedure is the fully-qualified name of the Notes's ListNotes RPC.
+	NotesListNotesProcedure = "/gprc_notes.Notes/ListNotes"
+	// NotesWatchNotesProcedure is the fully-qualified name of the Notes's WatchNotes RPC.
+	NotesWatchNotesProcedure = "/gprc_notes.Notes/WatchNotes"
+	// NotesBatchCreateProcedure is the fully-qualified name of the Notes's BatchCreate RPC.
+	NotesBatchCreateProcedure = "/gprc_notes.Notes/BatchCreate"
+	// NotesBatchUpdateProcedure is the fully-qualified name of the Notes's BatchUpdate RPC.
+	NotesBatchUpdateProcedure = "/gprc_notes.Notes/BatchUpdate"
+	// NotesBatchDeleteProcedure is the fully-qualified name of the Notes's BatchDelete RPC.
+	NotesBatchDeleteProcedure = "/gprc_notes.Notes/BatchDelete"
+	// NotesRefreshNoteProcedure is the fully-qualified name of the Notes's RefreshNote RPC.
+	NotesRefreshNoteProcedure = "/gprc_notes.Notes/RefreshNote"
+	// NotesSnoozeNoteProcedure is the fully-qualified name of the Notes's SnoozeNote RPC.
+	NotesSnoozeNoteProcedure = "/gprc_notes.Notes/SnoozeNote"
+	// NotesAcknowledgeNoteProcedure is the fully-qualified name of the Notes's AcknowledgeNote RPC.
+	NotesAcknowledgeNoteProcedure = "/gprc_notes.Notes/AcknowledgeNote"
+)
+
+// NotesClient is a client for the gprc_notes.Notes service.
+type NotesClient interface {
+	GetNotes(context.Context, *connect.Request[pb.GetNotesRequest]) (*connect.Response[pb.GetNotesResponse], error)
+	GetNote(context.Context, *connect.Request[pb.GetNoteRequest]) (*connect.Response[pb.GetNoteResponse], error)
+	CreateNote(context.Context, *connect.Request[pb.CreateNoteRequest]) (*connect.Response[pb.CreateNoteResponse], error)
+	DeleteNote(context.Context, *connect.Request[pb.DeleteNoteRequest]) (*connect.Response[pb.DeleteNoteResponse], error)
+	// UpdateNote sets the fields present in the body when served over REST.
+	UpdateNote(context.Context, *connect.Request[pb.UpdateNoteRequest]) (*connect.Response[pb.UpdateNoteResponse], error)
+	ListTags(context.Context, *connect.Request[pb.ListTagsRequest]) (*connect.Response[pb.ListTagsResponse], error)
+	SearchNotes(context.Context, *connect.Request[pb.SearchNotesRequest]) (*connect.Response[pb.SearchNotesResponse], error)
+	// ListNotes streams every matching note instead of returning pages.
+	ListNotes(context.Context, *connect.Request[pb.ListNotesRequest]) (*connect.ServerStreamForClient[pb.Note], error)
+	// WatchNotes streams note changes as they happen.
+	WatchNotes(context.Context, *connect.Request[pb.WatchNotesRequest]) (*connect.ServerStreamForClient[pb.NoteEvent], error)
+	// BatchCreate, BatchUpdate and BatchDelete change up to 1000 notes in one transaction.
+	BatchCreate(context.Context, *connect.Request[pb.BatchCreateRequest]) (*connect.Response[pb.BatchCreateResponse], error)
+	BatchUpdate(context.Context, *connect.Request[pb.BatchUpdateRequest]) (*connect.Response[pb.BatchUpdateResponse], error)
+	BatchDelete(context.Context, *connect.Request[pb.BatchDeleteRequest]) (*connect.Response[pb.BatchDeleteResponse], error)
+	// RefreshNote reschedules a due note after its delay and multiplies the delay by ten.
+	// Notes whose delay would exceed a year are deleted instead.
+	RefreshNote(context.Context, *connect.Request[pb.RefreshNoteRequest]) (*connect.Response[pb.RefreshNoteResponse], error)
+	// SnoozeNote postpones the reminder of a note and clears its acknowledgement.
+	SnoozeNote(context.Context, *connect.Request[pb.SnoozeNoteRequest]) (*connect.Response[pb.SnoozeNoteResponse], error)
+	// AcknowledgeNote stops the reminders of a note until it is snoozed or rescheduled.
+	AcknowledgeNote(context.Context, *connect.Request[pb.AcknowledgeNoteRequest]) (*connect.Response[pb.AcknowledgeNoteResponse], error)
+}
+
+// NewNotesClient constructs a client for the gprc_notes.Notes service. By default, it uses the
+// Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewNotesClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) NotesClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	notesMethods := pb.File_api_notes_proto.Services().ByName("Notes").Methods()
+	return &notesClient{
+		getNotes: connect.NewClient[pb.GetNotesRequest, pb.GetNotesResponse](
+			httpClient,
+			baseURL+NotesGetNotesProcedure,
+			connect.WithSchema(notesMethods.ByName("GetNotes")),
+			connect.WithClientOptions(opts...),
+		),
+		getNote: connect.NewClient[pb.GetNoteRequest, pb.GetNoteResponse](
+			httpClient,
+			baseURL+NotesGetNoteProcedure,
+			connect.WithSchema(notesMethods.ByName("GetNote")),
+			connect.WithClientOptions(opts...),
+		),
+		createNote: connect.NewClient[pb.CreateNoteRequest, pb.CreateNoteResponse](
+			httpClient,
+			baseURL+NotesCreateNoteProcedure,
+			connect.WithSchema(notesMethods.ByName("CreateNote")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteNote: connect.NewClient[pb.DeleteNoteRequest, pb.DeleteNoteResponse](
+			httpClient,
+			baseURL+NotesDeleteNoteProcedure,
+			connect.WithSchema(notesMethods.ByName("DeleteNote")),
+			connect.WithClientOptions(opts...),
+		),
+		updateNote: connect.NewClient[pb.UpdateNoteRequest, pb.UpdateNoteResponse](
+			httpClient,
+			baseURL+NotesUpdateNoteProcedure,
+			connect.WithSchema(notesMethods.ByName("UpdateNote")),
+			connect.WithClientOptions(opts...),
+		),
+		listTags: connect.NewClient[pb.ListTagsRequest, pb.ListTagsResponse](
+			httpClient,
+			baseURL+NotesListTagsProcedure,
+			connect.WithSchema(notesMethods.ByName("ListTags")),
+			connect.WithClientOptions(opts...),
+		),
+		searchNotes: connect.NewClient[pb.SearchNotesRequest, pb.SearchNotesResponse](
+			httpClient,
+			baseURL+NotesSearchNotesProcedure,
+			connect.WithSchema(notesMethods.ByName("SearchNotes")),
+			connect.WithClientOptions(opts...),
+		),
+		listNotes: connect.NewClient[pb.ListNotesRequest, pb.Note](
+			httpClient,
+			baseURL+NotesListNotesProcedure,
+			connect.WithSchema(notesMethods.ByName("ListNotes")),
+			connect.WithClientOptions(opts...),
+		),
+		watchNotes: connect.NewClient[pb.WatchNotesRequest, pb.NoteEvent](
+			httpClient,
+			baseURL+NotesWatchNotesProcedure,
+			connect.WithSchema(notesMethods.ByName("WatchNotes")),
+			connect.WithClientOptions(opts...),
+		),
+		batchCreate: connect.NewClient[pb.BatchCreateRequest, pb.BatchCreateResponse](
+			httpClient,
+			baseURL+NotesBatchCreateProcedure,
+			connect.WithSchema(notesMethods.ByName("BatchCreate")),
+			connect.WithClientOptions(opts...),
+		),
+		batchUpdate: connect.NewClient[pb.BatchUpdateRequest, pb.BatchUpdateResponse](
+			httpClient,
+			baseURL+NotesBatchUpdateProcedure,
+			connect.WithSchema(notesMethods.ByName("BatchUpdate")),
+			connect.WithClientOptions(opts...),
+		),
+		batchDelete: connect.NewClient[pb.BatchDeleteRequest, pb.BatchDeleteResponse](
+			httpClient,
+			baseURL+NotesBatchDeleteProcedure,
+			connect.WithSchema(notesMethods.ByName("BatchDelete")),
+			connect.WithClientOptions(opts...),
+		),
+		refreshNote: connect.NewClient[pb.RefreshNoteRequest, pb.RefreshNoteResponse](
+			httpClient,
+			baseURL+NotesRefreshNoteProcedure,
+			connect.WithSchema(notesMethods.ByName("RefreshNote")),
+			connect.WithClientOptions(opts...),
+		),
+		snoozeNote: connect.NewClient[pb.SnoozeNoteRequest, pb.SnoozeNoteResponse](
+			httpClient,
+			baseURL+NotesSnoozeNoteProcedure,
+			connect.WithSchema(notesMethods.ByName("SnoozeNote")),
+			connect.WithClientOptions(opts...),
+		),
+		acknowledgeNote: connect.NewClient[pb.AcknowledgeNoteRequest, pb.AcknowledgeNoteResponse](
+			httpClient,
+			baseURL+NotesAcknowledgeNoteProcedure,
+			connect.WithSchema(notesMethods.ByName("AcknowledgeNote")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// notesClient implements NotesClient.
+type notesClient struct {
+	getNotes        *connect.Client[pb.GetNotesRequest, pb.GetNotesResponse]
+	getNote         *connect.Client[pb.GetNoteRequest, pb.GetNoteResponse]
+	createNote      *connect.Client[pb.CreateNoteRequest, pb.CreateNoteResponse]
+	deleteNote      *connect.Client[pb.DeleteNoteRequest, pb.DeleteNoteResponse]
+	updateNote      *connect.Client[pb.UpdateNoteRequest, pb.UpdateNoteResponse]
+	listTags        *connect.Client[pb.ListTagsRequest, pb.ListTagsResponse]
+	searchNotes     *connect.Client[pb.SearchNotesRequest, pb.SearchNotesResponse]
+	listNotes       *connect.Client[pb.ListNotesRequest, pb.Note]
+	watchNotes      *connect.Client[pb.WatchNotesRequest, pb.NoteEvent]
+	batchCreate     *connect.Client[pb.BatchCreateRequest, pb.BatchCreateResponse]
+	batchUpdate     *connect.Client[pb.BatchUpdateRequest, pb.BatchUpdateResponse]
+	batchDelete     *connect.Client[pb.BatchDeleteRequest, pb.BatchDeleteResponse]
+	refreshNote     *connect.Client[pb.RefreshNoteRequest, pb.RefreshNoteResponse]
+	snoozeNote      *connect.Client[pb.SnoozeNoteRequest, pb.SnoozeNoteResponse]
+	acknowledgeNote *connect.Client[pb.AcknowledgeNoteRequest, pb.AcknowledgeNoteResponse]
+}
+
+// GetNotes calls gprc_notes.Notes.GetNotes.
+func (c *notesClient) GetNotes(ctx context.Context, req *connect.Request[pb.GetNotesRequest]) (*connect.Response[pb.GetNotesResponse], error) {
+	return c.getNotes.CallUnary(ctx, req)
+}
+
+// GetNote calls gprc_notes.Notes.GetNote.
+func (c *notesClient) GetNote(ctx context.Context, req *connect.Request[pb.GetNoteRequest]) (*connect.Response[pb.GetNoteResponse], error) {
+	return c.getNote.CallUnary(ctx, req)
+}
+
+// CreateNote calls gprc_notes.Notes.CreateNote.
+func (c *notesClient) CreateNote(ctx context.Context, req *connect.Request[pb.CreateNoteRequest]) (*connect.Response[pb.CreateNoteResponse], error) {
+	return c.createNote.CallUnary(ctx, req)
+}
+
+// DeleteNote calls gprc_notes.Notes.DeleteNote.
+func (c *notesClient) DeleteNote(ctx context.Context, req *connect.Request[pb.DeleteNoteRequest]) (*connect.Response[pb.DeleteNoteResponse], error) {
+	return c.deleteNote.CallUnary(ctx, req)
+}
+
+// UpdateNote calls gprc_notes.Notes.UpdateNote.
+func (c *notesClient) UpdateNote(ctx context.Context, req *connect.Request[pb.UpdateNoteRequest]) (*connect.Response[pb.UpdateNoteResponse], error) {
+	return c.updateNote.CallUnary(ctx, req)
+}
+
+// ListTags calls gprc_notes.Notes.ListTags.
+func (c *notesClient) ListTags(ctx context.Context, req *connect.Request[pb.ListTagsRequest]) (*connect.Response[pb.ListTagsResponse], error) {
+	return c.listTags.CallUnary(ctx, req)
+}
+
+// SearchNotes calls gprc_notes.Notes.SearchNotes.
+func (c *notesClient) SearchNotes(ctx context.Context, req *connect.Request[pb.SearchNotesRequest]) (*connect.Response[pb.SearchNotesResponse], error) {
+	return c.searchNotes.CallUnary(ctx, req)
+}
+
+// ListNotes calls gprc_notes.Notes.ListNotes.
+func (c *notesClient) ListNotes(ctx context.Context, req *connect.Request[pb.ListNotesRequest]) (*connect.ServerStreamForClient[pb.Note], error) {
+	return c.listNotes.CallServerStream(ctx, req)
+}
+
+// WatchNotes calls gprc_notes.Notes.WatchNotes.
+func (c *notesClient) WatchNotes(ctx context.Context, req *connect.Request[pb.WatchNotesRequest]) (*connect.ServerStreamForClient[pb.NoteEvent], error) {
+	return c.watchNotes.CallServerStream(ctx, req)
+}
+
+// BatchCreate calls gprc_notes.Notes.BatchCreate.
+func (c *notesClient) BatchCreate(ctx context.Context, req *connect.Request[pb.BatchCreateRequest]) (*connect.Response[pb.BatchCreateResponse], error) {
+	return c.batchCreate.CallUnary(ctx, req)
+}
+
+// BatchUpdate calls gprc_notes.Notes.BatchUpdate.
+func (c *notesClient) BatchUpdate(ctx context.Context, req *connect.Request[pb.BatchUpdateRequest]) (*connect.Response[pb.BatchUpdateResponse], error) {
+	return c.batchUpdate.CallUnary(ctx, req)
+}
+
+// BatchDelete calls gprc_notes.Notes.BatchDelete.
+func (c *notesClient) BatchDelete(ctx context.Context, req *connect.Request[pb.BatchDeleteRequest]) (*connect.Response[pb.BatchDeleteResponse], error) {
+	return c.batchDelete.CallUnary(ctx, req)
+}
+
+// RefreshNote calls gprc_notes.Notes.RefreshNote.
+func (c *notesClient) RefreshNote(ctx context.Context, req *connect.Request[pb.RefreshNoteRequest]) (*connect.Response[pb.RefreshNoteResponse], error) {
+	return c.refreshNote.CallUnary(ctx, req)
+}
+
+// SnoozeNote calls gprc_notes.Notes.SnoozeNote.
+func (c *notesClient) SnoozeNote(ctx context.Context, req *connect.Request[pb.SnoozeNoteRequest]) (*connect.Response[pb.SnoozeNoteResponse], error) {
+	return c.snoozeNote.CallUnary(ctx, req)
+}
+
+// AcknowledgeNote calls gprc_notes.Notes.AcknowledgeNote.
+func (c *notesClient) AcknowledgeNote(ctx context.Context, req *connect.Request[pb.AcknowledgeNoteRequest]) (*connect.Response[pb.AcknowledgeNoteResponse], error) {
+	return c.acknowledgeNote.CallUnary(ctx, req)
+}
+
+// NotesHandler is an implementation of the gprc_notes.Notes service.
+type NotesHandler interface {
+	GetNotes(context.Context, *connect.Request[pb.GetNotesRequest]) (*connect.Response[pb.GetNotesResponse], error)
+	GetNote(context.Context, *connect.Request[pb.GetNoteRequest]) (*connect.Response[pb.GetNoteResponse], error)
+	CreateNote(context.Context, *connect.Request[pb.CreateNoteRequest]) (*connect.Response[pb.CreateNoteResponse], error)
+	DeleteNote(context.Context, *connect.Request[pb.DeleteNoteRequest]) (*connect.Response[pb.DeleteNoteResponse], error)
+	// UpdateNote sets the fields present in the body when served over REST.
+	UpdateNote(context.Context, *connect.Request[pb.UpdateNoteRequest]) (*connect.Response[pb.UpdateNoteResponse], error)
+	ListTags(context.Context, *connect.Request[pb.ListTagsRequest]) (*connect.Response[pb.ListTagsResponse], error)
+	SearchNotes(context.Context, *connect.Request[pb.SearchNotesRequest]) (*connect.Response[pb.SearchNotesResponse], error)
+	// ListNotes streams every matching note instead of returning pages.
+	ListNotes(context.Context, *connect.Request[pb.ListNotesRequest], *connect.ServerStream[pb.Note]) error
+	// WatchNotes streams note changes as they happen.
+	WatchNotes(context.Context, *connect.Request[pb.WatchNotesRequest], *connect.ServerStream[pb.NoteEvent]) error
+	// BatchCreate, BatchUpdate and BatchDelete change up to 1000 notes in one transaction.
+	BatchCreate(context.Context, *connect.Request[pb.BatchCreateRequest]) (*connect.Response[pb.BatchCreateResponse], error)
+	BatchUpdate(context.Context, *connect.Request[pb.BatchUpdateRequest]) (*connect.Response[pb.BatchUpdateResponse], error)
+	BatchDelete(context.Context, *connect.Request[pb.BatchDeleteRequest]) (*connect.Response[pb.BatchDeleteResponse], error)
+	// RefreshNote reschedules a due note after its delay and multiplies the delay by ten.
+	// Notes whose delay would exceed a year are deleted instead.
+	RefreshNote(context.Context, *connect.Request[pb.RefreshNoteRequest]) (*connect.Response[pb.RefreshNoteResponse], error)
+	// SnoozeNote postpones the reminder of a note and clears its acknowledgement.
+	SnoozeNote(context.Context, *connect.Request[pb.SnoozeNoteRequest]) (*connect.Response[pb.SnoozeNoteResponse], error)
+	// AcknowledgeNote stops the reminders of a note until it is snoozed or rescheduled.
+	AcknowledgeNote(context.Context, *connect.Request[pb.AcknowledgeNoteRequest]) (*connect.Response[pb.AcknowledgeNoteResponse], error)
+}
+
+// NewNotesHandler builds an HTTP handler from the service implementation. It returns the path on
+// which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewNotesHandler(svc NotesHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	notesMethods := pb.File_api_notes_proto.Services().ByName("Notes").Methods()
+	notesGetNotesHandler := connect.NewUnaryHandler(
+		NotesGetNotesProcedure,
+		svc.GetNotes,
+		connect.WithSchema(notesMethods.ByName("GetNotes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	notesGetNoteHandler := connect.NewUnaryHandler(
+		NotesGetNoteProcedure,
+		svc.GetNote,
+		connect.WithSchema(notesMethods.ByName("GetNote")),
+		connect.WithHandlerOptions(opts...),
+	)
+	notesCreateNoteHandler := connect.NewUnaryHandler(
+		NotesCreateNoteProcedure,
+		svc.CreateNote,
+		connect.WithSchema(notesMethods.ByName("CreateNote")),
+		connect.WithHandlerOptions(opts...),
+	)
+	notesDeleteNoteHandler := connect.NewUnaryHandler(
+		NotesDeleteNoteProcedure,
+		svc.DeleteNote,
+		connect.WithSchema(notesMethods.ByName("DeleteNote")),
+		connect.WithHandlerOptions(opts...),
+	)
+	notesUpdateNoteHandler := connect.NewUnaryHandler(
+		NotesUpdateNoteProcedure,
+		svc.UpdateNote,
+		connect.WithSchema(notesMethods.ByName("UpdateNote")),
+		connect.WithHandlerOptions(opts...),
+	)
+	notesListTagsHandler := connect.NewUnaryHandler(
+		NotesListTagsProcedure,
+		svc.ListTags,
+		connect.WithSchema(notesMethods.ByName("ListTags")),
+		connect.WithHandlerOptions(opts...),
+	)
+	notesSearchNotesHandler := connect.NewUnaryHandler(
+		NotesSearchNotesProcedure,
+		svc.SearchNotes,
+		connect.WithSchema(notesMethods.ByName("SearchNotes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	notesListNotesHandler := connect.NewServerStreamHandler(
+		NotesListNotesProcedure,
+		svc.ListNotes,
+		connect.WithSchema(notesMethods.ByName("ListNotes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	notesWatchNotesHandler := connect.NewServerStreamHandler(
+		NotesWatchNotesProcedure,
+		svc.WatchNotes,
+		connect.WithSchema(notesMethods.ByName("WatchNotes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	notesBatchCreateHandler := connect.NewUnaryHandler(
+		NotesBatchCreateProcedure,
+		svc.BatchCreate,
+		connect.WithSchema(notesMethods.ByName("BatchCreate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	notesBatchUpdateHandler := connect.NewUnaryHandler(
+		NotesBatchUpdateProcedure,
+		svc.BatchUpdate,
+		connect.WithSchema(notesMethods.ByName("BatchUpdate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	notesBatchDeleteHandler := connect.NewUnaryHandler(
+		NotesBatchDeleteProcedure,
+		svc.BatchDelete,
+		connect.WithSchema(notesMethods.ByName("BatchDelete")),
+		connect.WithHandlerOptions(opts...),
+	)
+	notesRefreshNoteHandler := connect.NewUnaryHandler(
+		NotesRefreshNoteProcedure,
+		svc.RefreshNote,
+		connect.WithSchema(notesMethods.ByName("RefreshNote")),
+		connect.WithHandlerOptions(opts...),
+	)
+	notesSnoozeNoteHandler := connect.NewUnaryHandler(
+		NotesSnoozeNoteProcedure,
+		svc.SnoozeNote,
+		connect.WithSchema(notesMethods.ByName("SnoozeNote")),
+		connect.WithHandlerOptions(opts...),
+	)
+	notesAcknowledgeNoteHandler := connect.NewUnaryHandler(
+		NotesAcknowledgeNoteProcedure,
+		svc.AcknowledgeNote,
+		connect.WithSchema(notesMethods.ByName("AcknowledgeNote")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/gprc_notes.Notes/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case NotesGetNotesProcedure:
+			notesGetNotesHandler.ServeHTTP(w, r)
+		case NotesGetNoteProcedure:
+			notesGetNoteHandler.ServeHTTP(w, r)
+		case NotesCreateNoteProcedure:
+			notesCreateNoteHandler.ServeHTTP(w, r)
+		case NotesDeleteNoteProcedure:
+			notesDeleteNoteHandler.ServeHTTP(w, r)
+		case NotesUpdateNoteProcedure:
+			notesUpdateNoteHandler.ServeHTTP(w, r)
+		case NotesListTagsProcedure:
+			notesListTagsHandler.ServeHTTP(w, r)
+		case NotesSearchNotesProcedure:
+			notesSearchNotesHandler.ServeHTTP(w, r)
+		case NotesListNotesProcedure:
+			notesListNotesHandler.ServeHTTP(w, r)
+		case NotesWatchNotesProcedure:
+			notesWatchNotesHandler.ServeHTTP(w, r)
+		case NotesBatchCreateProcedure:
+			notesBatchCreateHandler.ServeHTTP(w, r)
+		case NotesBatchUpdateProcedure:
+			notesBatchUpdateHandler.ServeHTTP(w, r)
+		case NotesBatchDeleteProcedure:
+			notesBatchDeleteHandler.ServeHTTP(w, r)
+		case NotesRefreshNoteProcedure:
+			notesRefreshNoteHandler.ServeHTTP(w, r)
+		case NotesSnoozeNoteProcedure:
+			notesSnoozeNoteHandler.ServeHTTP(w, r)
+		case NotesAcknowledgeNoteProcedure:
+			notesAcknowledgeNoteHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedNotesHandler returns CodeUnimplemented from all methods.
+type UnimplementedNotesHandler struct{}
+
+func (UnimplementedNotesHandler) GetNotes(context.Context, *connect.Request[pb.GetNotesRequest]) (*connect.Response[pb.GetNotesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gprc_notes.Notes.GetNotes is not implemented"))
+}
+
+func (UnimplementedNotesHandler) GetNote(context.Context, *connect.Request[pb.GetNoteRequest]) (*connect.Response[pb.GetNoteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gprc_notes.Notes.GetNote is not implemented"))
+}
+
+func (UnimplementedNotesHandler) CreateNote(context.Context, *connect.Request[pb.CreateNoteRequest]) (*connect.Response[pb.CreateNoteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gprc_notes.Notes.CreateNote is not implemented"))
+}
+
+func (UnimplementedNotesHandler) DeleteNote(context.Context, *connect.Request[pb.DeleteNoteRequest]) (*connect.Response[pb.DeleteNoteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gprc_notes.Notes.DeleteNote is not implemented"))
+}
+
+func (UnimplementedNotesHandler) UpdateNote(context.Context, *connect.Request[pb.UpdateNoteRequest]) (*connect.Response[pb.UpdateNoteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gprc_notes.Notes.UpdateNote is not implemented"))
+}
+
+func (UnimplementedNotesHandler) ListTags(context.Context, *connect.Request[pb.ListTagsRequest]) (*connect.Response[pb.ListTagsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gprc_notes.Notes.ListTags is not implemented"))
+}
+
+func (UnimplementedNotesHandler) SearchNotes(context.Context, *connect.Request[pb.SearchNotesRequest]) (*connect.Response[pb.SearchNotesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gprc_notes.Notes.SearchNotes is not implemented"))
+}
+
+func (UnimplementedNotesHandler) ListNotes(context.Context, *connect.Request[pb.ListNotesRequest], *connect.ServerStream[pb.Note]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("gprc_notes.Notes.ListNotes is not implemented"))
+}
+
+func (UnimplementedNotesHandler) WatchNotes(context.Context, *connect.Request[pb.WatchNotesRequest], *connect.ServerStream[pb.NoteEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("gprc_notes.Notes.WatchNotes is not implemented"))
+}
+
+func (UnimplementedNotesHandler) BatchCreate(context.Context, *connect.Request[pb.BatchCreateRequest]) (*connect.Response[pb.BatchCreateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gprc_notes.Notes.BatchCreate is not implemented"))
+}
+
+func (UnimplementedNotesHandler) BatchUpdate(context.Context, *connect.Request[pb.BatchUpdateRequest]) (*connect.Response[pb.BatchUpdateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gprc_notes.Notes.BatchUpdate is not implemented"))
+}
+
+func (UnimplementedNotesHandler) BatchDelete(context.Context, *connect.Request[pb.BatchDeleteRequest]) (*connect.Response[pb.BatchDeleteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gprc_notes.Notes.BatchDelete is not implemented"))
+}
+
+func (UnimplementedNotesHandler) RefreshNote(context.Context, *connect.Request[pb.RefreshNoteRequest]) (*connect.Response[pb.RefreshNoteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gprc_notes.Notes.RefreshNote is not implemented"))
+}
+
+func (UnimplementedNotesHandler) SnoozeNote(context.Context, *connect.Request[pb.SnoozeNoteRequest]) (*connect.Response[pb.SnoozeNoteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gprc_notes.Notes.SnoozeNote is not implemented"))
+}
+
+func (UnimplementedNotesHandler) AcknowledgeNote(context.Context, *connect.Request[pb.AcknowledgeNoteRequest]) (*connect.Response[pb.AcknowledgeNoteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gprc_notes.Notes.AcknowledgeNote is not implemented"))
+}
@@ -3,48 +3,187 @@ package grpcserver
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
+	"notes/internal/notes/app"
+	"notes/internal/notes/events"
 	"notes/internal/notes/server"
 	"notes/internal/notes/server/grpcserver/interceptor"
 	"notes/internal/notes/server/grpcserver/pb"
 	"notes/internal/notes/storage"
 	"notes/internal/pkg/config"
 	"notes/internal/pkg/logger"
+	"notes/internal/pkg/models"
+	"notes/internal/pkg/tlsconfig"
+	"time"
 
 	"google.golang.org/grpc"
+	channelzservice "google.golang.org/grpc/channelz/service"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
+// healthInterval is how often the gRPC health status is refreshed from the readiness checks.
+const healthInterval = time.Second * 10
+
+// keepalivePolicy lets clients ping idle connections every 20 seconds, the
+// default policy closes connections pinged more often than every 5 minutes.
+var keepalivePolicy = keepalive.EnforcementPolicy{
+	MinTime:             time.Second * 20,
+	PermitWithoutStream: true,
+}
+
+var (
+	// ErrAdminWithoutAuth is returned by New for the Admin service without an
+	// authenticator, which would leave it open to every caller.
+	ErrAdminWithoutAuth = errors.New("the admin service needs auth enabled")
+	// ErrNoAdminServices is returned by New for the Admin service without the
+	// services allowed to call it.
+	ErrNoAdminServices = errors.New("the admin service needs grpcServer.adminServices")
+)
+
 type Server struct {
 	a      server.App
 	cfg    config.GRPCServer
+	opts   server.Options
 	server *grpc.Server
+	health *health.Server
+	stats  *connStats
+	// certs is nil without TLS.
+	certs *tlsconfig.Reloader
 	pb.NotesServer
 }
 
-func New(a server.App, logg logger.Logger, cfg config.GRPCServer) *Server {
+func New(a server.App, logg logger.Logger, cfg config.GRPCServer, opts ...server.Option) (*Server, error) {
+	o := server.NewOptions(opts...)
+	switch {
+	case cfg.Admin && o.Auth == nil:
+		return nil, ErrAdminWithoutAuth
+	case cfg.Admin && len(cfg.AdminServices) == 0:
+		return nil, ErrNoAdminServices
+	}
+
+	proxies, err := interceptor.ParseProxies(cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := interceptor.NewChain()
+	chain.Add(interceptor.Recovery,
+		interceptor.RecoveryInterceptor(logg), interceptor.RecoveryStreamInterceptor(logg))
+	chain.Add(interceptor.RequestID,
+		interceptor.RequestIDInterceptor(), interceptor.RequestIDStreamInterceptor())
+	chain.Add(interceptor.Tracing,
+		interceptor.TracingInterceptor(), interceptor.TracingStreamInterceptor())
+	chain.Add(interceptor.Logging,
+		interceptor.LoggingInterceptor(logg), interceptor.LoggingStreamInterceptor(logg))
+	chain.Add(interceptor.Metrics,
+		interceptor.MetricsInterceptor(), interceptor.MetricsStreamInterceptor())
+	if o.Auth != nil {
+		chain.Add(interceptor.Auth,
+			interceptor.AuthInterceptor(o.Auth), interceptor.AuthStreamInterceptor(o.Auth))
+		if cfg.Admin {
+			prefix := "/" + pb.Admin_ServiceDesc.ServiceName + "/"
+			chain.Add(interceptor.Auth,
+				interceptor.AllowServicesInterceptor(prefix, cfg.AdminServices),
+				interceptor.AllowServicesStreamInterceptor(prefix, cfg.AdminServices))
+		}
+	}
+	if o.RateLimit != nil {
+		chain.Add(interceptor.RateLimit,
+			interceptor.RateLimitInterceptor(o.RateLimit, proxies),
+			interceptor.RateLimitStreamInterceptor(o.RateLimit, proxies))
+	}
+	if o.Idempotency != nil {
+		chain.Add(interceptor.Idempotency,
+			interceptor.IdempotencyInterceptor(o.Idempotency, logg, proxies,
+				pb.Notes_CreateNote_FullMethodName,
+				pb.Notes_UpdateNote_FullMethodName,
+				pb.Notes_DeleteNote_FullMethodName,
+				pb.Notes_BatchCreate_FullMethodName,
+				pb.Notes_BatchUpdate_FullMethodName,
+				pb.Notes_BatchDelete_FullMethodName,
+				pb.Notes_RefreshNote_FullMethodName,
+				pb.Notes_SnoozeNote_FullMethodName,
+				pb.Notes_AcknowledgeNote_FullMethodName,
+			), nil)
+	}
+
+	order := cfg.Interceptors
+	if len(order) == 0 {
+		order = interceptor.DefaultOrder
+	}
+	unary, stream, err := chain.Build(order)
+	if err != nil {
+		return nil, fmt.Errorf("bad interceptor order: %w", err)
+	}
+
+	stats := &connStats{}
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+		grpc.StatsHandler(stats),
+		grpc.KeepaliveEnforcementPolicy(keepalivePolicy),
+	}
+	var certs *tlsconfig.Reloader
+	if cfg.TLS.Enabled {
+		// The certificates are loaded by Start, which can report errors.
+		certs = tlsconfig.New(cfg.TLS)
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(certs.ServerConfig())))
+	}
+
 	return &Server{
-		a:   a,
-		cfg: cfg,
-		server: grpc.NewServer(
-			grpc.UnaryInterceptor(
-				grpc.UnaryServerInterceptor(
-					interceptor.LoggingInterceptor(logg)),
-			),
-		),
+		a:      a,
+		cfg:    cfg,
+		opts:   o,
+		health: health.NewServer(),
+		stats:  stats,
+		certs:  certs,
+		server: grpc.NewServer(serverOpts...),
+	}, nil
+}
+
+// Register adds the notes, health and the services enabled in the config to gs.
+func (s *Server) Register(gs *grpc.Server) {
+	pb.RegisterNotesServer(gs, s)
+	healthpb.RegisterHealthServer(gs, s.health)
+	if s.cfg.Admin {
+		pb.RegisterAdminServer(gs, &adminServer{info: s.opts.Admin, stats: s.stats, started: time.Now()})
+	}
+	if s.cfg.Reflection {
+		reflection.Register(gs)
+	}
+	if s.cfg.Channelz {
+		channelzservice.RegisterChannelzServiceToServer(gs)
 	}
 }
 
 func (s *Server) Start(ctx context.Context) error {
+	if s.certs != nil {
+		if err := s.certs.Load(); err != nil {
+			return err
+		}
+	}
 	lis, err := net.Listen("tcp", s.cfg.Host+s.cfg.Port)
 	if err != nil {
 		return err
 	}
 
 	defer lis.Close()
-	pb.RegisterNotesServer(s.server, s)
+	return s.Serve(ctx, lis)
+}
+
+// Serve serves the registered services with the interceptor chain on lis until
+// Shutdown.
+func (s *Server) Serve(ctx context.Context, lis net.Listener) error {
+	s.Register(s.server)
+
+	go s.watchHealth(ctx)
 
 	select {
 	case <-ctx.Done():
@@ -59,18 +198,43 @@ func (s *Server) Start(ctx context.Context) error {
 }
 
 func (s *Server) Shutdown(_ context.Context) error {
+	s.health.Shutdown()
 	s.server.GracefulStop()
 	return nil
 }
 
+// watchHealth reports the readiness checks through the grpc.health.v1 service until ctx is done.
+func (s *Server) watchHealth(ctx context.Context) {
+	t := time.NewTicker(healthInterval)
+	defer t.Stop()
+
+	for {
+		st := healthpb.HealthCheckResponse_SERVING
+		if !s.opts.Health.Ready(ctx).OK() {
+			st = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		s.health.SetServingStatus("", st)
+		s.health.SetServingStatus(pb.Notes_ServiceDesc.ServiceName, st)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
 func (s *Server) GetNotes(ctx context.Context, r *pb.GetNotesRequest) (*pb.GetNotesResponse, error) {
-	notes, err := s.a.GetNotes(ctx, r.TimeInterval.AsDuration())
+	page, err := s.a.GetNotes(ctx, ToNotesFilter(r))
 	if err != nil {
+		if errors.Is(err, storage.ErrInvalidPageToken) || errors.Is(err, storage.ErrInvalidSort) {
+			return &pb.GetNotesResponse{}, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return &pb.GetNotesResponse{}, status.Error(codes.Internal, err.Error())
 	}
 
-	pbNotes := ToPBNotes(notes)
-	return &pb.GetNotesResponse{Notes: pbNotes}, nil
+	pbNotes := ToPBNotes(page.Notes)
+	return &pb.GetNotesResponse{Notes: pbNotes, NextPageToken: page.NextPageToken}, nil
 }
 
 func (s *Server) GetNote(ctx context.Context, req *pb.GetNoteRequest) (*pb.GetNoteResponse, error) {
@@ -87,15 +251,27 @@ func (s *Server) GetNote(ctx context.Context, req *pb.GetNoteRequest) (*pb.GetNo
 }
 
 func (s *Server) CreateNote(ctx context.Context, req *pb.CreateNoteRequest) (*pb.CreateNoteResponse, error) {
-	note := ToNote(req.Note)
-	if err := s.a.CreateNote(ctx, note); err != nil {
+	note, err := s.a.CreateNote(ctx, ToNote(req.Note))
+	if err != nil {
+		switch {
+		case errors.Is(err, app.ErrQuotaExceeded):
+			return &pb.CreateNoteResponse{}, status.Error(codes.ResourceExhausted, err.Error())
+		case errors.Is(err, storage.ErrUnknownOwner):
+			return &pb.CreateNoteResponse{}, status.Error(codes.PermissionDenied, err.Error())
+		}
 		return &pb.CreateNoteResponse{}, status.Error(codes.Internal, err.Error())
 	}
-	return &pb.CreateNoteResponse{}, nil
+	return &pb.CreateNoteResponse{Note: ToPBNote(note)}, nil
 }
 
 func (s *Server) DeleteNote(ctx context.Context, req *pb.DeleteNoteRequest) (*pb.DeleteNoteResponse, error) {
-	if err := s.a.DeleteNote(ctx, req.ID); err != nil {
+	if err := s.a.DeleteNote(ctx, req.ID, req.ExpectedVersion); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			return &pb.DeleteNoteResponse{}, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, storage.ErrVersionConflict):
+			return &pb.DeleteNoteResponse{}, status.Error(codes.Aborted, err.Error())
+		}
 		return &pb.DeleteNoteResponse{}, status.Error(codes.Internal, err.Error())
 	}
 	return &pb.DeleteNoteResponse{}, nil
@@ -103,23 +279,104 @@ func (s *Server) DeleteNote(ctx context.Context, req *pb.DeleteNoteRequest) (*pb
 
 func (s *Server) UpdateNote(ctx context.Context, req *pb.UpdateNoteRequest) (*pb.UpdateNoteResponse, error) {
 	note := ToNote(req.Note)
-	md, _ := metadata.FromIncomingContext(ctx)
+	note.Version = req.ExpectedVersion
 
-	r := md.Get("refreshed")
-	if len(r) > 0 {
-		if r[0] != "true" {
-			return &pb.UpdateNoteResponse{}, status.Error(codes.InvalidArgument, "invalid metadata")
+	fields, err := ToNoteFields(req.GetUpdateMask())
+	if err != nil {
+		return &pb.UpdateNoteResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.a.UpdateNote(ctx, note, fields); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrInvalidField), errors.Is(err, storage.ErrNotEnoughArguments):
+			return &pb.UpdateNoteResponse{}, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, storage.ErrNotFound):
+			return &pb.UpdateNoteResponse{}, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, storage.ErrVersionConflict):
+			return &pb.UpdateNoteResponse{}, status.Error(codes.Aborted, err.Error())
+		}
+		return &pb.UpdateNoteResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.UpdateNoteResponse{}, nil
+}
+
+func (s *Server) ListTags(ctx context.Context, _ *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	tags, err := s.a.ListTags(ctx)
+	if err != nil {
+		return &pb.ListTagsResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ListTagsResponse{Tags: ToPBTags(tags)}, nil
+}
+
+func (s *Server) SearchNotes(ctx context.Context, r *pb.SearchNotesRequest) (*pb.SearchNotesResponse, error) {
+	page, err := s.a.Search(ctx, models.SearchQuery{
+		Query:  r.GetQuery(),
+		Limit:  int(r.GetLimit()),
+		Offset: int(r.GetOffset()),
+	})
+	if err != nil {
+		if errors.Is(err, app.ErrEmptyQuery) {
+			return &pb.SearchNotesResponse{}, status.Error(codes.InvalidArgument, err.Error())
 		}
-		if err := s.a.RefreshNote(ctx, note); err != nil {
-			if errors.Is(err, storage.ErrNotFound) {
-				return &pb.UpdateNoteResponse{}, status.Error(codes.NotFound, err.Error())
+		return &pb.SearchNotesResponse{}, status.Error(codes.Internal, err.Error())
+	}
+	return ToPBSearchPage(page), nil
+}
+
+// ListNotes streams the matching notes, reading them from storage one page at a time.
+func (s *Server) ListNotes(r *pb.ListNotesRequest, stream pb.Notes_ListNotesServer) error {
+	ctx := stream.Context()
+	filter := ToListNotesFilter(r)
+	filter.PageSize = app.MaxPageSize
+	for {
+		page, err := s.a.GetNotes(ctx, filter)
+		if err != nil {
+			if errors.Is(err, storage.ErrInvalidSort) {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			return status.Error(codes.Internal, err.Error())
+		}
+		for _, n := range page.Notes {
+			if err := stream.Send(ToPBNote(n)); err != nil {
+				return err
 			}
-			return &pb.UpdateNoteResponse{}, status.Error(codes.Internal, err.Error())
 		}
+		if page.NextPageToken == "" {
+			return nil
+		}
+		filter.PageToken = page.NextPageToken
 	}
+}
 
-	if err := s.a.UpdateNote(ctx, note); err != nil {
-		return &pb.UpdateNoteResponse{}, status.Error(codes.Internal, err.Error())
+// WatchNotes streams the changes of the caller's notes until the client goes away.
+func (s *Server) WatchNotes(r *pb.WatchNotesRequest, stream pb.Notes_WatchNotesServer) error {
+	if s.opts.Events == nil {
+		return status.Error(codes.Unimplemented, "note events are disabled")
+	}
+
+	ctx := stream.Context()
+	sub, err := s.opts.Events.Subscribe(r.ResumeAfter, events.ForContext(ctx, ToNoteEventTypes(r.Types)...))
+	if err != nil {
+		if errors.Is(err, events.ErrResumeExpired) {
+			return status.Error(codes.OutOfRange, err.Error())
+		}
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case e, ok := <-sub.Events():
+			if !ok {
+				if errors.Is(sub.Err(), events.ErrSlowSubscriber) {
+					return status.Error(codes.ResourceExhausted, sub.Err().Error())
+				}
+				return status.Error(codes.Unavailable, sub.Err().Error())
+			}
+			if err := stream.Send(ToPBNoteEvent(e)); err != nil {
+				return err
+			}
+		}
 	}
-	return &pb.UpdateNoteResponse{}, nil
 }
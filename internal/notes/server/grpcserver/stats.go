@@ -0,0 +1,48 @@
+package grpcserver
+
+import (
+	"context"
+	"sync/atomic"
+
+	"google.golang.org/grpc/stats"
+)
+
+// connStats counts the connections and calls of the server for the Admin service.
+type connStats struct {
+	open, total                 atomic.Int64
+	inFlight, succeeded, failed atomic.Int64
+}
+
+var _ stats.Handler = (*connStats)(nil)
+
+func (c *connStats) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (c *connStats) HandleRPC(_ context.Context, s stats.RPCStats) {
+	switch s := s.(type) {
+	case *stats.Begin:
+		c.inFlight.Add(1)
+	case *stats.End:
+		c.inFlight.Add(-1)
+		if s.Error != nil {
+			c.failed.Add(1)
+			return
+		}
+		c.succeeded.Add(1)
+	}
+}
+
+func (c *connStats) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (c *connStats) HandleConn(_ context.Context, s stats.ConnStats) {
+	switch s.(type) {
+	case *stats.ConnBegin:
+		c.open.Add(1)
+		c.total.Add(1)
+	case *stats.ConnEnd:
+		c.open.Add(-1)
+	}
+}
@@ -1,22 +1,46 @@
 package grpcserver
 
 import (
+	"fmt"
 	"notes/internal/notes/server/grpcserver/pb"
+	"notes/internal/notes/storage"
 	"notes/internal/pkg/models"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func ToNote(n *pb.Note) models.Note {
-	return models.Note{
+	if n == nil {
+		return models.Note{}
+	}
+	note := models.Note{
 		ID:          n.ID,
 		Title:       n.Title,
 		Description: n.Description,
-		DateAdded:   n.DateAdded.AsTime(),
-		DateNotify:  n.DateNotify.AsTime(),
+		DateAdded:   toTime(n.DateAdded),
+		DateNotify:  toTime(n.DateNotify),
 		Delay:       time.Duration(n.Delay),
+		OwnerID:     n.OwnerID,
+		Tags:        n.Tags,
+		Version:     n.Version,
+	}
+	if n.AcknowledgedAt != nil {
+		t := n.AcknowledgedAt.AsTime()
+		note.AcknowledgedAt = &t
+	}
+	return note
+}
+
+// toTime keeps unset timestamps zero, AsTime would return the Unix epoch.
+func toTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
 	}
+	return ts.AsTime()
 }
 
 func ToNotes(notesPB []*pb.Note) []models.Note {
@@ -38,12 +62,221 @@ func ToPBNotes(notes []models.Note) []*pb.Note {
 }
 
 func ToPBNote(n models.Note) *pb.Note {
-	return &pb.Note{
+	note := &pb.Note{
 		ID:          n.ID,
 		Title:       n.Title,
 		Description: n.Description,
 		DateAdded:   timestamppb.New(n.DateAdded),
 		DateNotify:  timestamppb.New(n.DateNotify),
 		Delay:       int64(n.Delay),
+		OwnerID:     n.OwnerID,
+		Tags:        n.Tags,
+		Version:     n.Version,
+	}
+	if n.AcknowledgedAt != nil {
+		note.AcknowledgedAt = timestamppb.New(*n.AcknowledgedAt)
+	}
+	return note
+}
+
+func ToNotesFilter(r *pb.GetNotesRequest) models.NotesFilter {
+	return models.NotesFilter{
+		Interval:     r.TimeInterval.AsDuration(),
+		Tags:         r.Tags,
+		MatchAllTags: r.TagMatch == pb.TagMatch_TAG_MATCH_ALL,
+		SortBy:       sortFields[r.SortBy],
+		Descending:   r.Descending,
+		PageSize:     int(r.PageSize),
+		PageToken:    r.PageToken,
+	}
+}
+
+var sortFields = map[pb.NotesSort]string{
+	pb.NotesSort_NOTES_SORT_ID:          "",
+	pb.NotesSort_NOTES_SORT_DATE_NOTIFY: models.SortByDateNotify,
+	pb.NotesSort_NOTES_SORT_DATE_ADDED:  models.SortByDateAdded,
+}
+
+// ToPBNotesSort is the inverse of the sort mapping in ToNotesFilter.
+func ToPBNotesSort(sortBy string) pb.NotesSort {
+	for s, field := range sortFields {
+		if field == sortBy && field != "" {
+			return s
+		}
+	}
+	return pb.NotesSort_NOTES_SORT_ID
+}
+
+func ToPBTags(tags []models.TagCount) []*pb.Tag {
+	tagsPB := make([]*pb.Tag, 0, len(tags))
+	for _, t := range tags {
+		tagsPB = append(tagsPB, &pb.Tag{Name: t.Name, Count: int64(t.Count)})
+	}
+	return tagsPB
+}
+
+func ToPBSearchPage(page models.SearchPage) *pb.SearchNotesResponse {
+	results := make([]*pb.SearchResult, 0, len(page.Results))
+	for _, r := range page.Results {
+		results = append(results, &pb.SearchResult{Note: ToPBNote(r.Note), Rank: r.Rank, Snippet: r.Snippet})
+	}
+	return &pb.SearchNotesResponse{Results: results, NextOffset: int32(page.NextOffset)}
+}
+
+func ToSearchPage(r *pb.SearchNotesResponse) models.SearchPage {
+	results := make([]models.SearchResult, 0, len(r.GetResults()))
+	for _, res := range r.GetResults() {
+		results = append(results, models.SearchResult{Note: ToNote(res.Note), Rank: res.Rank, Snippet: res.Snippet})
+	}
+	return models.SearchPage{Results: results, NextOffset: int(r.GetNextOffset())}
+}
+
+func ToListNotesFilter(r *pb.ListNotesRequest) models.NotesFilter {
+	return ToNotesFilter(&pb.GetNotesRequest{
+		TimeInterval: r.TimeInterval,
+		Tags:         r.Tags,
+		TagMatch:     r.TagMatch,
+		SortBy:       r.SortBy,
+		Descending:   r.Descending,
+	})
+}
+
+var eventTypes = map[pb.NoteEventType]models.NoteEventType{
+	pb.NoteEventType_NOTE_EVENT_TYPE_CREATED: models.NoteCreated,
+	pb.NoteEventType_NOTE_EVENT_TYPE_UPDATED: models.NoteUpdated,
+	pb.NoteEventType_NOTE_EVENT_TYPE_DELETED: models.NoteDeleted,
+	pb.NoteEventType_NOTE_EVENT_TYPE_DUE:     models.NoteDue,
+}
+
+func ToNoteEventTypes(types []pb.NoteEventType) []models.NoteEventType {
+	res := make([]models.NoteEventType, 0, len(types))
+	for _, t := range types {
+		if et, ok := eventTypes[t]; ok {
+			res = append(res, et)
+		}
+	}
+	return res
+}
+
+func ToPBNoteEventTypes(types []models.NoteEventType) []pb.NoteEventType {
+	res := make([]pb.NoteEventType, 0, len(types))
+	for _, t := range types {
+		res = append(res, toPBNoteEventType(t))
+	}
+	return res
+}
+
+func toPBNoteEventType(t models.NoteEventType) pb.NoteEventType {
+	for pt, et := range eventTypes {
+		if et == t {
+			return pt
+		}
+	}
+	return pb.NoteEventType_NOTE_EVENT_TYPE_UNSPECIFIED
+}
+
+func ToPBNoteEvent(e models.NoteEvent) *pb.NoteEvent {
+	ev := &pb.NoteEvent{
+		Id:     e.ID,
+		Type:   toPBNoteEventType(e.Type),
+		NoteID: e.NoteID,
+		Time:   timestamppb.New(e.Time),
+	}
+	if e.Note != nil {
+		ev.Note = ToPBNote(*e.Note)
+	}
+	return ev
+}
+
+func ToNoteEvent(e *pb.NoteEvent) models.NoteEvent {
+	ev := models.NoteEvent{
+		ID:     e.Id,
+		Type:   eventTypes[e.Type],
+		NoteID: e.NoteID,
+		Time:   e.Time.AsTime(),
+	}
+	if e.Note != nil {
+		n := ToNote(e.Note)
+		ev.OwnerID = n.OwnerID
+		ev.Note = &n
+	}
+	return ev
+}
+
+// noteMaskFields maps the Note field names allowed in an update mask to the model fields.
+var noteMaskFields = map[string]models.NoteField{
+	"title":       models.FieldTitle,
+	"description": models.FieldDescription,
+	"dateNotify":  models.FieldDateNotify,
+	"delay":       models.FieldDelay,
+	"tags":        models.FieldTags,
+}
+
+// ToNoteFields returns nil for a nil mask.
+func ToNoteFields(mask *fieldmaskpb.FieldMask) ([]models.NoteField, error) {
+	if mask == nil {
+		return nil, nil
+	}
+	fields := make([]models.NoteField, 0, len(mask.Paths))
+	for _, p := range mask.Paths {
+		f, ok := noteMaskFields[p]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", storage.ErrInvalidField, p)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// ToPBFieldMask returns nil for no fields.
+func ToPBFieldMask(fields []models.NoteField) *fieldmaskpb.FieldMask {
+	if len(fields) == 0 {
+		return nil
+	}
+	mask := &fieldmaskpb.FieldMask{}
+	for _, f := range fields {
+		for path, mf := range noteMaskFields {
+			if mf == f {
+				mask.Paths = append(mask.Paths, path)
+			}
+		}
+	}
+	return mask
+}
+
+func ToBatchMode(m pb.BatchMode) models.BatchMode {
+	if m == pb.BatchMode_BATCH_MODE_PARTIAL {
+		return models.BatchPartial
+	}
+	return models.BatchAtomic
+}
+
+func ToPBBatchResults(results []models.BatchResult) []*pb.BatchResult {
+	res := make([]*pb.BatchResult, len(results))
+	for i, r := range results {
+		res[i] = &pb.BatchResult{ID: r.ID, Version: r.Version}
+		if r.Err != nil {
+			res[i].Code, res[i].Error = int32(batchCode(r.Err)), r.Err.Error()
+		}
+	}
+	return res
+}
+
+func ToPBBatchMode(m models.BatchMode) pb.BatchMode {
+	if m == models.BatchPartial {
+		return pb.BatchMode_BATCH_MODE_PARTIAL
+	}
+	return pb.BatchMode_BATCH_MODE_ATOMIC
+}
+
+// ToBatchResults returns the failures of items as status errors.
+func ToBatchResults(results []*pb.BatchResult) []models.BatchResult {
+	res := make([]models.BatchResult, len(results))
+	for i, r := range results {
+		res[i] = models.BatchResult{ID: r.GetID(), Version: r.GetVersion()}
+		if c := codes.Code(r.GetCode()); c != codes.OK {
+			res[i].Err = status.Error(c, r.GetError())
+		}
 	}
+	return res
 }
@@ -2,11 +2,131 @@ package server
 
 import (
 	"context"
+	"net/http"
 	"notes/internal/notes/app"
+	"notes/internal/notes/events"
+	"notes/internal/notes/idempotency"
+	"notes/internal/notes/ratelimit"
+	"notes/internal/pkg/auth"
+	"notes/internal/pkg/config"
+	"notes/internal/pkg/health"
+	"notes/internal/pkg/logger"
 	"notes/internal/pkg/models"
+	"time"
 )
 
 type App interface {
-	app.Storage
-	RefreshNote(context.Context, models.Note) error
+	app.NoteCreater
+	app.NotesGetter
+	app.NoteGetter
+	app.NoteDeleter
+	app.NoteUpdater
+	app.TagsLister
+	app.NotesSearcher
+	app.BatchWriter
+	ImportNotes(ctx context.Context, notes []models.Note, mode models.BatchMode) ([]models.BatchResult, error)
+	RefreshNote(ctx context.Context, id uint64) (_ models.Note, deleted bool, err error)
+	SnoozeNote(ctx context.Context, id uint64, until time.Time) (models.Note, error)
+	AcknowledgeNote(ctx context.Context, id uint64) (models.Note, error)
+}
+
+// Options holds the optional dependencies shared by the REST and gRPC servers.
+type Options struct {
+	Health *health.Checker
+	// Auth is nil when the API is served without authentication.
+	Auth *auth.Authenticator
+	// Events is nil when note change streams are disabled.
+	Events *events.Bus
+	// Idempotency is nil when idempotency keys are ignored.
+	Idempotency *idempotency.Keys
+	// RateLimit is nil when requests are not limited.
+	RateLimit *ratelimit.Limiter
+	// Admin is what the gRPC Admin service reports, nil when it is unknown.
+	Admin *AdminInfo
+	// Connect is mounted by the REST server on ConnectPath, nil when the
+	// Connect and gRPC-Web API is disabled or has its own port.
+	Connect     http.Handler
+	ConnectPath string
+}
+
+// AdminInfo describes the running service to the gRPC Admin service.
+type AdminInfo struct {
+	Config config.Config
+	Logger logger.Logger
+	// MigrationVersion returns the latest applied database migration.
+	MigrationVersion func(context.Context) (int64, error)
+	// Users backs the user and API key RPCs, nil disables them.
+	Users UserStore
+	// Tokens signs the tokens of users, nil when no JWT secret is set.
+	Tokens *auth.Authenticator
+}
+
+// UserStore provisions users and their API keys.
+type UserStore interface {
+	CreateUser(ctx context.Context, name string) (models.User, error)
+	GetUser(ctx context.Context, id uint64) (models.User, error)
+	CreateAPIKey(ctx context.Context, p auth.Principal) (string, error)
+	RevokeAPIKey(ctx context.Context, keyHash string) error
+}
+
+type Option func(*Options)
+
+// WithHealth sets the checker behind the health endpoints.
+func WithHealth(h *health.Checker) Option {
+	return func(o *Options) {
+		o.Health = h
+	}
+}
+
+// WithAuthenticator requires valid credentials on every request.
+func WithAuthenticator(a *auth.Authenticator) Option {
+	return func(o *Options) {
+		o.Auth = a
+	}
+}
+
+// WithEvents serves the note change streams from bus.
+func WithEvents(bus *events.Bus) Option {
+	return func(o *Options) {
+		o.Events = bus
+	}
+}
+
+// WithIdempotency replays the results of mutating requests retried with the same idempotency key.
+func WithIdempotency(k *idempotency.Keys) Option {
+	return func(o *Options) {
+		o.Idempotency = k
+	}
+}
+
+// WithRateLimiter limits the request rate of every caller.
+func WithRateLimiter(l *ratelimit.Limiter) Option {
+	return func(o *Options) {
+		o.RateLimit = l
+	}
+}
+
+// WithAdminInfo reports info through the gRPC Admin service.
+func WithAdminInfo(info AdminInfo) Option {
+	return func(o *Options) {
+		o.Admin = &info
+	}
+}
+
+// WithConnect serves the Connect and gRPC-Web handler h under path on the REST port.
+func WithConnect(path string, h http.Handler) Option {
+	return func(o *Options) {
+		o.ConnectPath, o.Connect = path, h
+	}
+}
+
+func NewOptions(opts ...Option) Options {
+	var o Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.Health == nil {
+		o.Health = health.New(time.Second * 5)
+	}
+	return o
 }
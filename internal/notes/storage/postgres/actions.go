@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/models"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// returningNote makes an UPDATE return the changed note in the order expected by scanNote.
+var returningNote = "RETURNING " + strings.Join(noteColumns, ", ")
+
+// RefreshNote moves the notify date of a note on by its delay and multiplies the
+// delay by ten. When the new delay would exceed maxDelay the note is deleted
+// instead and deleted is true.
+func (s *Storage) RefreshNote(ctx context.Context, id uint64, maxDelay time.Duration,
+) (_ models.Note, deleted bool, err error) {
+	if id == 0 {
+		return models.Note{}, false, storage.ErrFieldUnspecified
+	}
+	query, args, err := squirrel.Select("delay").From("notes").
+		Where(squirrel.Eq{"id": id}).
+		Where(ownerScope(ctx)).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(squirrel.Dollar).ToSql()
+	if err != nil {
+		return models.Note{}, false, err
+	}
+
+	ctx, done := instrument(ctx, "RefreshNote", query)
+	defer func() { done(err) }()
+
+	var note models.Note
+	err = pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		// The row stays locked until the refresh is committed.
+		var delay time.Duration
+		if err := tx.QueryRow(ctx, query, args...).Scan(&delay); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return storage.ErrNotFound
+			}
+			return err
+		}
+
+		if delay*10 > maxDelay {
+			deleted = true
+			q, a, err := deleteStatement(ctx, id, 0)
+			if err != nil {
+				return err
+			}
+			return execDelete(ctx, tx, id, 0, q, a)
+		}
+
+		q, a, err := actionStatement(ctx, id, squirrel.Update("notes").
+			Set("date_notify", squirrel.Expr("date_notify + delay")).
+			Set("delay", squirrel.Expr("delay * 10")))
+		if err != nil {
+			return err
+		}
+		note, err = queryNote(ctx, tx, q, a)
+		return err
+	})
+	if err != nil {
+		return models.Note{}, false, err
+	}
+	return note, deleted, nil
+}
+
+// SnoozeNote sets the notify date of a note to until and clears its acknowledgement.
+func (s *Storage) SnoozeNote(ctx context.Context, id uint64, until time.Time) (_ models.Note, err error) {
+	query, args, err := actionStatement(ctx, id, squirrel.Update("notes").
+		Set("date_notify", until).
+		Set("acknowledged_at", nil))
+	if err != nil {
+		return models.Note{}, err
+	}
+
+	ctx, done := instrument(ctx, "SnoozeNote", query)
+	defer func() { done(err) }()
+
+	// Snoozing an acknowledged note activates its reminder again.
+	var note models.Note
+	err = pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		return s.withinQuotas(ctx, tx, func() error {
+			var err error
+			note, err = queryNote(ctx, tx, query, args)
+			return err
+		})
+	})
+	if err != nil {
+		return models.Note{}, err
+	}
+	return note, nil
+}
+
+// AcknowledgeNote marks the reminder of a note as seen, so that it is no longer due.
+// Acknowledging a note again keeps the first acknowledgement time.
+func (s *Storage) AcknowledgeNote(ctx context.Context, id uint64) (_ models.Note, err error) {
+	query, args, err := actionStatement(ctx, id, squirrel.Update("notes").
+		Set("acknowledged_at", squirrel.Expr("COALESCE(acknowledged_at, NOW())")))
+	if err != nil {
+		return models.Note{}, err
+	}
+
+	ctx, done := instrument(ctx, "AcknowledgeNote", query)
+	defer func() { done(err) }()
+
+	return queryNote(ctx, s.db, query, args)
+}
+
+// actionStatement limits qr to note id, bumps its version and returns the changed note.
+func actionStatement(ctx context.Context, id uint64, qr squirrel.UpdateBuilder) (string, []any, error) {
+	if id == 0 {
+		return "", nil, storage.ErrFieldUnspecified
+	}
+	return qr.Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": id}).
+		Where(ownerScope(ctx)).
+		Suffix(returningNote).
+		PlaceholderFormat(squirrel.Dollar).ToSql()
+}
+
+func queryNote(ctx context.Context, q querier, query string, args []any) (models.Note, error) {
+	n, err := scanNote(q.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Note{}, storage.ErrNotFound
+		}
+		return models.Note{}, err
+	}
+	return n, nil
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/auth"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// PrincipalByAPIKey returns the principal of a key that has not been revoked.
+func (s *Storage) PrincipalByAPIKey(ctx context.Context, keyHash string) (_ auth.Principal, err error) {
+	query := `SELECT principal_id, name, kind FROM api_keys WHERE key_hash = $1 AND revoked_at IS NULL`
+
+	ctx, done := instrument(ctx, "PrincipalByAPIKey", query)
+	defer func() { done(err) }()
+
+	var p auth.Principal
+	if err = s.db.QueryRow(ctx, query, keyHash).Scan(&p.ID, &p.Name, &p.Kind); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return auth.Principal{}, storage.ErrNotFound
+		}
+		return auth.Principal{}, err
+	}
+	return p, nil
+}
+
+// CreateAPIKey stores a new key for p and returns it. The key itself is not kept.
+func (s *Storage) CreateAPIKey(ctx context.Context, p auth.Principal) (_ string, err error) {
+	query := `INSERT INTO api_keys(name, key_hash, kind, principal_id) VALUES ($1, $2, $3, $4)`
+
+	ctx, done := instrument(ctx, "CreateAPIKey", query)
+	defer func() { done(err) }()
+
+	key, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return "", err
+	}
+	if _, err = s.db.Exec(ctx, query, p.Name, hash, p.Kind, p.ID); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// RevokeAPIKey disables the key with the given hash.
+func (s *Storage) RevokeAPIKey(ctx context.Context, keyHash string) (err error) {
+	query := `UPDATE api_keys SET revoked_at = NOW() WHERE key_hash = $1 AND revoked_at IS NULL`
+
+	ctx, done := instrument(ctx, "RevokeAPIKey", query)
+	defer func() { done(err) }()
+
+	tag, err := s.db.Exec(ctx, query, keyHash)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/models"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// copyMinNotes is the size from which atomic batches of new notes are inserted with COPY.
+const copyMinNotes = 64
+
+// CreateNotes inserts notes in one transaction, see runBatch for the modes.
+func (s *Storage) CreateNotes(ctx context.Context, notes []models.Note,
+	mode models.BatchMode,
+) (_ []models.BatchResult, err error) {
+	ctx, done := instrument(ctx, "CreateNotes", insertNoteQuery)
+	defer func() { done(err) }()
+
+	if mode == models.BatchAtomic && len(notes) >= copyMinNotes {
+		return s.copyNotes(ctx, notes)
+	}
+	return runBatch(ctx, s, mode, len(notes), true, func(tx pgx.Tx, i int) (models.BatchResult, error) {
+		id, err := s.insertNote(ctx, tx, notes[i])
+		return models.BatchResult{ID: id, Version: 1}, err
+	})
+}
+
+// UpdateNotes applies updates in one transaction, see runBatch for the modes.
+func (s *Storage) UpdateNotes(ctx context.Context, updates []models.NoteUpdate,
+	mode models.BatchMode,
+) (_ []models.BatchResult, err error) {
+	ctx, done := instrument(ctx, "UpdateNotes", "UPDATE notes")
+	defer func() { done(err) }()
+
+	// Rescheduling acknowledged notes activates their reminders again.
+	reactivates := slices.ContainsFunc(updates, func(u models.NoteUpdate) bool {
+		return slices.Contains(u.Fields, models.FieldDateNotify)
+	})
+	return runBatch(ctx, s, mode, len(updates), reactivates, func(tx pgx.Tx, i int) (models.BatchResult, error) {
+		note := updates[i].Note
+		r := models.BatchResult{ID: note.ID}
+
+		u, err := updateStatement(ctx, note, updates[i].Fields)
+		if err != nil {
+			return r, err
+		}
+		r.Version, err = s.execUpdate(ctx, tx, note, u)
+		return r, err
+	})
+}
+
+// DeleteNotes deletes notes in one transaction, see runBatch for the modes.
+func (s *Storage) DeleteNotes(ctx context.Context, refs []models.NoteRef,
+	mode models.BatchMode,
+) (_ []models.BatchResult, err error) {
+	ctx, done := instrument(ctx, "DeleteNotes", "DELETE FROM notes")
+	defer func() { done(err) }()
+
+	return runBatch(ctx, s, mode, len(refs), false, func(tx pgx.Tx, i int) (models.BatchResult, error) {
+		ref := refs[i]
+		r := models.BatchResult{ID: ref.ID}
+
+		query, args, err := deleteStatement(ctx, ref.ID, ref.Version)
+		if err != nil {
+			return r, err
+		}
+		return r, execDelete(ctx, tx, ref.ID, ref.Version, query, args)
+	})
+}
+
+// runBatch runs op for n items in one transaction. In BatchAtomic mode the first
+// failing item rolls back the others and is returned as a *storage.BatchError. In
+// BatchPartial mode every item runs in a savepoint and its failure is set in its
+// result. Failures that are not caused by the item, such as a lost connection,
+// fail the whole batch in both modes, as does going over the quotas when they
+// are checked.
+func runBatch(ctx context.Context, s *Storage, mode models.BatchMode, n int, quotas bool,
+	op func(tx pgx.Tx, i int) (models.BatchResult, error),
+) ([]models.BatchResult, error) {
+	results := make([]models.BatchResult, n)
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		if quotas {
+			return s.withinQuotas(ctx, tx, func() error {
+				return runItems(ctx, tx, mode, results, op)
+			})
+		}
+		return runItems(ctx, tx, mode, results, op)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// runItems runs op for every result in tx, see runBatch.
+func runItems(ctx context.Context, tx pgx.Tx, mode models.BatchMode, results []models.BatchResult,
+	op func(tx pgx.Tx, i int) (models.BatchResult, error),
+) error {
+	for i := range results {
+		if mode == models.BatchAtomic {
+			r, err := op(tx, i)
+			if err != nil {
+				if itemErr := itemError(err); itemErr != nil {
+					return &storage.BatchError{Index: i, Err: itemErr}
+				}
+				return err
+			}
+			results[i] = r
+			continue
+		}
+
+		// BeginFunc on a transaction uses a savepoint.
+		err := pgx.BeginFunc(ctx, tx, func(sp pgx.Tx) error {
+			var err error
+			results[i], err = op(sp, i)
+			return err
+		})
+		if err != nil {
+			itemErr := itemError(err)
+			if itemErr == nil {
+				return err
+			}
+			results[i].Err = itemErr
+		}
+	}
+	return nil
+}
+
+// itemError returns err when it was caused by the data of a batch item and nil
+// otherwise. Values the database rejects are reported as storage.ErrInvalidField.
+func itemError(err error) error {
+	for _, target := range []error{
+		storage.ErrUnknownOwner,
+		storage.ErrNotFound,
+		storage.ErrVersionConflict,
+		storage.ErrInvalidField,
+		storage.ErrFieldUnspecified,
+		storage.ErrNotEnoughArguments,
+	} {
+		if errors.Is(err, target) {
+			return err
+		}
+	}
+
+	var pgErr *pgconn.PgError
+	// Class 22 is data exceptions, such as a too long title, 23 integrity violations.
+	if errors.As(err, &pgErr) && (strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23")) {
+		return fmt.Errorf("%w: %s", storage.ErrInvalidField, pgErr.Message)
+	}
+	return nil
+}
+
+// copyNotes inserts notes with COPY, which is all or nothing. Their ids are taken
+// from the sequence beforehand, as COPY does not return them.
+func (s *Storage) copyNotes(ctx context.Context, notes []models.Note) ([]models.BatchResult, error) {
+	results := make([]models.BatchResult, len(notes))
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		return s.withinQuotas(ctx, tx, func() error {
+			return s.copyNotesTx(ctx, tx, notes, results)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// copyNotesTx copies notes in tx and sets their results.
+func (s *Storage) copyNotesTx(ctx context.Context, tx pgx.Tx, notes []models.Note,
+	results []models.BatchResult,
+) error {
+	rows, err := tx.Query(ctx,
+		`SELECT nextval(pg_get_serial_sequence('notes', 'id')) FROM generate_series(1, $1)`, len(notes))
+	if err != nil {
+		return err
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uint64])
+	if err != nil {
+		return err
+	}
+
+	tags := make([][]string, len(notes))
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"notes"},
+		[]string{"id", "title", "description", "date_added", "date_notify", "delay", "owner_id"},
+		pgx.CopyFromSlice(len(notes), func(i int) ([]any, error) {
+			n := notes[i]
+			tags[i] = n.Tags
+			results[i] = models.BatchResult{ID: ids[i], Version: 1}
+			return []any{ids[i], n.Title, n.Description, n.DateAdded, n.DateNotify, n.Delay,
+				ownerIDArg(n.OwnerID)}, nil
+		}))
+	if err != nil {
+		return copyError(ownerError(err))
+	}
+
+	if err := addTags(ctx, tx, ids, tags); err != nil {
+		return err
+	}
+	return s.updateSearch(ctx, tx, ids...)
+}
+
+// copyError tells the item that made COPY fail from the line in the error context.
+func copyError(err error) error {
+	itemErr := itemError(err)
+	if itemErr == nil {
+		return err
+	}
+	var (
+		pgErr *pgconn.PgError
+		line  int
+	)
+	if errors.As(err, &pgErr) {
+		if _, scanErr := fmt.Sscanf(pgErr.Where, "COPY notes, line %d", &line); scanErr == nil && line > 0 {
+			return &storage.BatchError{Index: line - 1, Err: itemErr}
+		}
+	}
+	return itemErr
+}
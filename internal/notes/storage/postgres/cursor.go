@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/models"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// cursor is the position of the last note of a page. It is handed to clients as an
+// opaque page token and is only valid for the sort order it was created with.
+type cursor struct {
+	SortBy string     `json:"s"`
+	Desc   bool       `json:"d,omitempty"`
+	Value  *time.Time `json:"v,omitempty"`
+	ID     uint64     `json:"id"`
+}
+
+func newCursor(filter models.NotesFilter, last models.Note) cursor {
+	c := cursor{SortBy: filter.SortBy, Desc: filter.Descending, ID: last.ID}
+	switch filter.SortBy {
+	case models.SortByDateNotify:
+		c.Value = &last.DateNotify
+	case models.SortByDateAdded:
+		c.Value = &last.DateAdded
+	}
+	return c
+}
+
+func (c cursor) encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(token string, filter models.NotesFilter) (cursor, error) {
+	var c cursor
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, storage.ErrInvalidPageToken
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, storage.ErrInvalidPageToken
+	}
+	if c.SortBy != filter.SortBy || c.Desc != filter.Descending || (c.SortBy != models.SortByID) != (c.Value != nil) {
+		return c, fmt.Errorf("%w: token was issued for another sort order", storage.ErrInvalidPageToken)
+	}
+	return c, nil
+}
+
+// after selects the notes following the cursor in its sort order.
+func (c cursor) after() squirrel.Sqlizer {
+	cmp := ">"
+	if c.Desc {
+		cmp = "<"
+	}
+	if c.SortBy == models.SortByID {
+		return squirrel.Expr("id "+cmp+" ?", c.ID)
+	}
+	return squirrel.Expr(fmt.Sprintf("(%s, id) %s (?, ?)", c.SortBy, cmp), *c.Value, c.ID)
+}
+
+// orderBy returns the ORDER BY clauses for a sort column, id breaks ties.
+func orderBy(sortBy string, desc bool) []string {
+	dir := " ASC"
+	if desc {
+		dir = " DESC"
+	}
+	if sortBy == models.SortByID {
+		return []string{"id" + dir}
+	}
+	return []string{sortBy + dir, "id" + dir}
+}
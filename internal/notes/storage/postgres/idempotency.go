@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"notes/internal/notes/idempotency"
+	"notes/internal/notes/storage"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// reserveAttempts bounds the retries when a key is released between the failed
+// insert and the lookup of its record.
+const reserveAttempts = 3
+
+// ReserveIdempotencyKey inserts r, taking over an expired record with the same key.
+// When a live record exists it is returned with reserved false.
+func (s *Storage) ReserveIdempotencyKey(ctx context.Context, r idempotency.Record,
+	ttl time.Duration,
+) (_ idempotency.Record, reserved bool, err error) {
+	insert := `INSERT INTO idempotency_keys(scope, key, fingerprint, expires_at)
+	VALUES ($1, $2, $3, NOW() + make_interval(secs => $4))
+	ON CONFLICT (scope, key) DO UPDATE SET fingerprint = EXCLUDED.fingerprint,
+		status = NULL, header = NULL, body = NULL, created_at = NOW(), expires_at = EXCLUDED.expires_at
+	WHERE idempotency_keys.expires_at < NOW()`
+	query := `SELECT fingerprint, status, header, body FROM idempotency_keys WHERE scope = $1 AND key = $2`
+
+	ctx, done := instrument(ctx, "ReserveIdempotencyKey", insert)
+	defer func() { done(err) }()
+
+	for i := 0; i < reserveAttempts; i++ {
+		var tag pgconn.CommandTag
+		tag, err = s.db.Exec(ctx, insert, r.Scope, r.Key, r.Fingerprint, ttl.Seconds())
+		if err != nil {
+			return idempotency.Record{}, false, err
+		}
+		if tag.RowsAffected() == 1 {
+			return r, true, nil
+		}
+
+		stored := idempotency.Record{Scope: r.Scope, Key: r.Key}
+		var (
+			status *int
+			header *http.Header
+		)
+		err = s.db.QueryRow(ctx, query, r.Scope, r.Key).
+			Scan(&stored.Fingerprint, &status, &header, &stored.Body)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return idempotency.Record{}, false, err
+		}
+		if status != nil {
+			stored.Done, stored.Code = true, *status
+		}
+		if header != nil {
+			stored.Header = *header
+		}
+		return stored, false, nil
+	}
+	return idempotency.Record{}, false, idempotency.ErrInProgress
+}
+
+// SaveIdempotencyKey stores the response of a reserved record.
+func (s *Storage) SaveIdempotencyKey(ctx context.Context, r idempotency.Record) (err error) {
+	query := `UPDATE idempotency_keys SET status = $3, header = $4, body = $5 WHERE scope = $1 AND key = $2`
+
+	ctx, done := instrument(ctx, "SaveIdempotencyKey", query)
+	defer func() { done(err) }()
+
+	tag, err := s.db.Exec(ctx, query, r.Scope, r.Key, r.Code, r.Header, r.Body)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey deletes a record that has no response yet.
+func (s *Storage) ReleaseIdempotencyKey(ctx context.Context, r idempotency.Record) (err error) {
+	query := `DELETE FROM idempotency_keys WHERE scope = $1 AND key = $2 AND status IS NULL`
+
+	ctx, done := instrument(ctx, "ReleaseIdempotencyKey", query)
+	defer func() { done(err) }()
+
+	_, err = s.db.Exec(ctx, query, r.Scope, r.Key)
+	return err
+}
+
+// PurgeIdempotencyKeys deletes expired records and returns their number.
+func (s *Storage) PurgeIdempotencyKeys(ctx context.Context) (_ int64, err error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at < NOW()`
+
+	ctx, done := instrument(ctx, "PurgeIdempotencyKeys", query)
+	defer func() { done(err) }()
+
+	tag, err := s.db.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/models"
+	"time"
+)
+
+// notifyChannel is the channel notes triggers publish changes on, see migration 007.
+const notifyChannel = "note_events"
+
+type noteNotification struct {
+	Op      string `json:"op"`
+	ID      uint64 `json:"id"`
+	OwnerID uint64 `json:"owner_id"`
+}
+
+var noteEventTypes = map[string]models.NoteEventType{
+	"insert": models.NoteCreated,
+	"update": models.NoteUpdated,
+	"delete": models.NoteDeleted,
+}
+
+// Listen calls publish for every note change committed to the database, by any
+// instance, until ctx is done. A lost connection is re-established.
+func (s *Storage) Listen(ctx context.Context, publish func(models.NoteEvent)) error {
+	for {
+		err := s.listen(ctx, publish)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		log.Printf("listening to note events... error %s\n", err.Error())
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (s *Storage) listen(ctx context.Context, publish func(models.NoteEvent)) error {
+	c, err := s.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection stays subscribed, so it must not go back to the pool.
+	conn := c.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return err
+	}
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var p noteNotification
+		if err := json.Unmarshal([]byte(n.Payload), &p); err != nil {
+			log.Printf("bad note event payload %q: %s\n", n.Payload, err.Error())
+			continue
+		}
+		e := models.NoteEvent{Type: noteEventTypes[p.Op], NoteID: p.ID, OwnerID: p.OwnerID}
+		if e.Type == "" {
+			continue
+		}
+
+		if e.Type != models.NoteDeleted {
+			note, err := s.GetNote(ctx, p.ID)
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				// Deleted before we got to it, its delete event follows.
+				continue
+			case err != nil:
+				return err
+			}
+			e.Note = &note
+		}
+		publish(e)
+	}
+}
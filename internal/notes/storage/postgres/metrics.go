@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"notes/internal/notes/storage"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("notes/internal/notes/storage/postgres")
+
+var queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "notes",
+	Subsystem: "db",
+	Name:      "query_duration_seconds",
+	Help:      "Latency of Postgres queries by operation.",
+	Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 14),
+}, []string{"operation", "status"})
+
+// instrument starts a span and a latency measurement for a query.
+// The returned func must be called with the query's result.
+func instrument(ctx context.Context, op, query string) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := tracer.Start(ctx, "postgres."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperation(op),
+			semconv.DBStatement(query),
+		),
+	)
+
+	return ctx, func(err error) {
+		status := "ok"
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			status = "error"
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		queryDuration.WithLabelValues(op, status).Observe(time.Since(start).Seconds())
+		span.End()
+	}
+}
+
+type poolCollector struct {
+	s *Storage
+
+	acquiredConns *prometheus.Desc
+	idleConns     *prometheus.Desc
+	totalConns    *prometheus.Desc
+	maxConns      *prometheus.Desc
+	acquireCount  *prometheus.Desc
+	acquireWait   *prometheus.Desc
+	emptyAcquire  *prometheus.Desc
+}
+
+// NewPoolCollector exports the connection pool statistics of s.
+func NewPoolCollector(s *Storage) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("notes", "db_pool", name), help, nil, nil)
+	}
+	return &poolCollector{
+		s:             s,
+		acquiredConns: desc("acquired_conns", "Number of currently acquired connections."),
+		idleConns:     desc("idle_conns", "Number of currently idle connections."),
+		totalConns:    desc("total_conns", "Total number of connections in the pool."),
+		maxConns:      desc("max_conns", "Maximum size of the pool."),
+		acquireCount:  desc("acquire_total", "Cumulative count of successful acquires."),
+		acquireWait:   desc("acquire_duration_seconds_total", "Total time spent waiting for a connection."),
+		emptyAcquire:  desc("empty_acquire_total", "Acquires that had to wait for a connection."),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireWait
+	ch <- c.emptyAcquire
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	st := c.s.db.Stat()
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(st.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(st.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(st.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(st.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(st.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireWait, prometheus.CounterValue, st.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquire, prometheus.CounterValue, float64(st.EmptyAcquireCount()))
+}
@@ -6,40 +6,84 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/url"
 	"notes/internal/notes/storage"
+	"notes/internal/pkg/auth"
 	"notes/internal/pkg/config"
 	"notes/internal/pkg/models"
+	"notes/internal/pkg/tlsconfig"
 	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib" // used for driver
 	"github.com/pressly/goose/v3"
 )
 
 // TODO: DB requests should create their own context with timeout, which is set dut to config.
 type Storage struct {
-	db *pgx.Conn
+	db *pgxpool.Pool
+	// searchLang is the text search configuration, e.g. "english" or "simple".
+	searchLang string
+	quotas     config.Quotas
 }
 
 func New(ctx context.Context, cfg config.Config) (*Storage, error) {
 	dbURL := "postgres://" + cfg.DB.Username + ":" + cfg.DB.Password + "@" +
-		cfg.DB.Host + cfg.DB.Port + "/" + cfg.DB.DB
-	db, err := connect(ctx, dbURL)
+		cfg.DB.Host + cfg.DB.Port + "/" + cfg.DB.DB + sslParams(cfg.DB.TLS)
+	poolCfg, err := pgxpool.ParseConfig(dbURL)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.DB.TLS.Enabled {
+		// Replaces the config made from the URL parameters by one that reloads the certificates.
+		tlsCfg, err := tlsconfig.Client(cfg.DB.TLS)
+		if err != nil {
+			return nil, err
+		}
+		if tlsCfg.ServerName == "" {
+			tlsCfg.ServerName = cfg.DB.Host
+		}
+		poolCfg.ConnConfig.TLSConfig = tlsCfg
+		poolCfg.ConnConfig.Fallbacks = nil
+	}
+	db, err := connect(ctx, poolCfg)
 	if err != nil {
 		return nil, err
 	}
 
 	if err = applyMigrations(dbURL, cfg); err != nil {
+		db.Close()
 		return nil, err
 	}
+	if err = syncSearchLanguage(ctx, db, cfg.DB.SearchLanguage); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("can not rebuild the search vectors: %w", err)
+	}
 
-	return &Storage{db: db}, nil
+	return &Storage{db: db, searchLang: cfg.DB.SearchLanguage, quotas: cfg.Quotas}, nil
 }
 
-func connect(ctx context.Context, dbURL string) (*pgx.Conn, error) {
-	db, err := pgx.Connect(ctx, dbURL)
+// sslParams are the URL parameters of cfg, which the migrations connect with.
+func sslParams(cfg config.TLS) string {
+	if !cfg.Enabled {
+		return ""
+	}
+	q := url.Values{"sslmode": {"verify-full"}}
+	if cfg.CAFile != "" {
+		q.Set("sslrootcert", cfg.CAFile)
+	}
+	if cfg.CertFile != "" {
+		q.Set("sslcert", cfg.CertFile)
+		q.Set("sslkey", cfg.KeyFile)
+	}
+	return "?" + q.Encode()
+}
+
+func connect(ctx context.Context, poolCfg *pgxpool.Config) (*pgxpool.Pool, error) {
+	db, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -49,6 +93,7 @@ loop:
 	for {
 		select {
 		case <-ctx.Done():
+			db.Close()
 			return nil, storage.ErrContextCancelled
 		default:
 			err = db.Ping(ctx)
@@ -59,6 +104,7 @@ loop:
 			target := new(pgconn.PgError)
 			if errors.As(err, &target) {
 				if target.Code == "3D000" {
+					db.Close()
 					return nil, err
 				}
 			}
@@ -94,70 +140,218 @@ func applyMigrations(dbURL string, cfg config.Config) error {
 	return nil
 }
 
-func (s *Storage) CreateNote(ctx context.Context, note models.Note) error {
-	// TODO: can return id so that we can add the note to cache i.e. Redis to have
-	// access to it without requesting db, like this: redisDB.Add(Key: id, Value: note).
-	query := `INSERT INTO notes(title, description, date_added, date_notify, delay) 
-	VALUES ($1, $2, $3, $4, $5)`
+// Ping checks that a connection to the database can be acquired.
+func (s *Storage) Ping(ctx context.Context) error {
+	return s.db.Ping(ctx)
+}
+
+// MigrationVersion returns the latest applied goose migration.
+func (s *Storage) MigrationVersion(ctx context.Context) (int64, error) {
+	query := `SELECT version_id FROM goose_db_version WHERE is_applied ORDER BY id DESC LIMIT 1`
+
+	var v int64
+	if err := s.db.QueryRow(ctx, query).Scan(&v); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return v, nil
+}
+
+// CheckMigrations returns a health check that fails unless the database is at version.
+func (s *Storage) CheckMigrations(version int64) func(context.Context) error {
+	return func(ctx context.Context) error {
+		v, err := s.MigrationVersion(ctx)
+		if err != nil {
+			return err
+		}
+		if v != version {
+			return fmt.Errorf("%w: want %d, have %d", storage.ErrMigrationVersion, version, v)
+		}
+		return nil
+	}
+}
+
+// Close releases all pool connections.
+func (s *Storage) Close() {
+	s.db.Close()
+}
 
-	_, err := s.db.Exec(
-		ctx, query,
+// noteColumns are selected in the order expected by scanNote.
+var noteColumns = []string{
+	"id", "title", "description", "date_added", "date_notify", "delay", "COALESCE(owner_id, 0)", "version",
+	"acknowledged_at",
+	`ARRAY(SELECT t.name FROM note_tags nt JOIN tags t ON t.id = nt.tag_id
+		WHERE nt.note_id = notes.id ORDER BY t.name)`,
+}
+
+func scanNote(row pgx.Row) (models.Note, error) {
+	n := models.Note{}
+	err := row.Scan(&n.ID, &n.Title, &n.Description, &n.DateAdded, &n.DateNotify, &n.Delay, &n.OwnerID,
+		&n.Version, &n.AcknowledgedAt, &n.Tags)
+	return n, err
+}
+
+// ownerScope limits a query to the notes of the user principal in ctx.
+// Services and unauthenticated callers are not limited.
+func ownerScope(ctx context.Context) squirrel.Sqlizer {
+	ownerID, ok := auth.OwnerFromContext(ctx)
+	if !ok {
+		return squirrel.And{}
+	}
+	return squirrel.Eq{"owner_id": ownerID}
+}
+
+// CreateNote inserts note and returns it with its id and version.
+func (s *Storage) CreateNote(ctx context.Context, note models.Note) (_ models.Note, err error) {
+	ctx, done := instrument(ctx, "CreateNote", insertNoteQuery)
+	defer func() { done(err) }()
+
+	err = pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		return s.withinQuotas(ctx, tx, func() error {
+			var err error
+			note.ID, err = s.insertNote(ctx, tx, note)
+			return err
+		})
+	})
+	if err != nil {
+		return models.Note{}, err
+	}
+	note.Version = 1
+	return note, nil
+}
+
+const insertNoteQuery = `INSERT INTO notes(title, description, date_added, date_notify, delay, owner_id) 
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+
+// insertNote inserts note with its tags and returns its id.
+func (s *Storage) insertNote(ctx context.Context, tx pgx.Tx, note models.Note) (uint64, error) {
+	var id uint64
+	err := tx.QueryRow(
+		ctx, insertNoteQuery,
 		note.Title,
 		note.Description,
 		note.DateAdded,  // Format("2006-01-02T15:04:05-07:00")
 		note.DateNotify, // Format("2006-01-02T15:04:05-07:00")
 		note.Delay,
-	)
+		ownerIDArg(note.OwnerID),
+	).Scan(&id)
 	if err != nil {
-		return err
+		return 0, ownerError(err)
 	}
 
-	return nil
+	if err := setTags(ctx, tx, id, note.Tags); err != nil {
+		return 0, err
+	}
+	return id, s.updateSearch(ctx, tx, id)
 }
 
-func (s *Storage) GetNotes(ctx context.Context, interval time.Duration) ([]models.Note, error) {
-	querySq := squirrel.Select("id", "title", "description", "date_added", "date_notify", "delay").From("notes")
-	if interval > 0 {
+// ownerError returns storage.ErrUnknownOwner for the foreign key violation of a
+// note whose owner_id has no user, such as that of a token for a deleted user.
+func ownerError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "notes_owner_id_fkey" {
+		return fmt.Errorf("%w: %w", storage.ErrUnknownOwner, err)
+	}
+	return err
+}
+
+// ownerIDArg stores notes without an owner with a NULL owner_id.
+func ownerIDArg(ownerID uint64) *uint64 {
+	if ownerID == 0 {
+		return nil
+	}
+	return &ownerID
+}
+
+// GetNotes returns a page of notes matching filter. Pages are keyset paginated: the
+// next page token holds the sort value and id of the last note.
+func (s *Storage) GetNotes(ctx context.Context, filter models.NotesFilter) (_ models.NotesPage, err error) {
+	switch filter.SortBy {
+	case "":
+		filter.SortBy = models.SortByID
+	case models.SortByID, models.SortByDateNotify, models.SortByDateAdded:
+	default:
+		return models.NotesPage{}, fmt.Errorf("%w: %s", storage.ErrInvalidSort, filter.SortBy)
+	}
+
+	querySq := squirrel.Select(noteColumns...).From("notes").
+		Where(ownerScope(ctx)).
+		OrderBy(orderBy(filter.SortBy, filter.Descending)...).
+		PlaceholderFormat(squirrel.Dollar)
+	if filter.Interval > 0 {
 		querySq = querySq.Where(squirrel.And{
-			squirrel.Expr(fmt.Sprintf("date_notify < NOW() +  '%s'", interval.String())),
+			squirrel.Expr(fmt.Sprintf("date_notify < NOW() +  '%s'", filter.Interval.String())),
 			squirrel.Expr("date_notify >= NOW()"),
+			squirrel.Eq{"acknowledged_at": nil},
 		})
 	}
-	query, _, err := querySq.ToSql()
+	if len(filter.Tags) > 0 {
+		querySq = querySq.Where(tagsFilter(filter.Tags, filter.MatchAllTags))
+	}
+	if filter.PageToken != "" {
+		c, err := decodeCursor(filter.PageToken, filter)
+		if err != nil {
+			return models.NotesPage{}, err
+		}
+		querySq = querySq.Where(c.after())
+	}
+	if filter.PageSize > 0 {
+		// One extra row tells whether there is a next page.
+		querySq = querySq.Limit(uint64(filter.PageSize) + 1)
+	}
+	query, args, err := querySq.ToSql()
 	if err != nil {
-		return nil, err
+		return models.NotesPage{}, err
 	}
 
-	rows, err := s.db.Query(ctx, query)
+	ctx, done := instrument(ctx, "GetNotes", query)
+	defer func() { done(err) }()
+
+	rows, err := s.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, err
-	}
-	if rows.Err() != nil {
-		return nil, err
+		return models.NotesPage{}, err
 	}
-
 	defer rows.Close()
+
 	notes := make([]models.Note, 0, 32)
 	for rows.Next() {
-		n := models.Note{}
-		err := rows.Scan(&n.ID, &n.Title, &n.Description, &n.DateAdded, &n.DateNotify, &n.Delay)
-		if err != nil {
-			return nil, err
+		var n models.Note
+		if n, err = scanNote(rows); err != nil {
+			return models.NotesPage{}, err
 		}
 		notes = append(notes, n)
 	}
-	return notes, nil
+	if err = rows.Err(); err != nil {
+		return models.NotesPage{}, err
+	}
+
+	page := models.NotesPage{Notes: notes}
+	if filter.PageSize > 0 && len(notes) > filter.PageSize {
+		page.Notes = notes[:filter.PageSize]
+		page.NextPageToken = newCursor(filter, page.Notes[filter.PageSize-1]).encode()
+	}
+	return page, nil
 }
 
-func (s *Storage) GetNote(ctx context.Context, id uint64) (models.Note, error) {
+func (s *Storage) GetNote(ctx context.Context, id uint64) (_ models.Note, err error) {
 	if id == 0 {
 		return models.Note{}, storage.ErrFieldUnspecified
 	}
-	query := "SELECT id, title, description, date_added, date_notify, delay FROM notes WHERE id = $1"
+	query, args, err := squirrel.Select(noteColumns...).From("notes").
+		Where(squirrel.Eq{"id": id}).
+		Where(ownerScope(ctx)).
+		PlaceholderFormat(squirrel.Dollar).ToSql()
+	if err != nil {
+		return models.Note{}, err
+	}
 
-	n := models.Note{}
-	if err := s.db.QueryRow(ctx, query, id).Scan(
-		&n.ID, &n.Title, &n.Description, &n.DateAdded, &n.DateNotify, &n.Delay); err != nil {
+	ctx, done := instrument(ctx, "GetNote", query)
+	defer func() { done(err) }()
+
+	n, err := scanNote(s.db.QueryRow(ctx, query, args...))
+	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return models.Note{}, storage.ErrNotFound
 		}
@@ -167,68 +361,176 @@ func (s *Storage) GetNote(ctx context.Context, id uint64) (models.Note, error) {
 	return n, nil
 }
 
-func (s *Storage) DeleteNote(ctx context.Context, id uint64) error {
+// DeleteNote deletes a note. A non-zero version makes it fail with ErrVersionConflict
+// when the note has another version.
+func (s *Storage) DeleteNote(ctx context.Context, id uint64, version uint64) (err error) {
+	query, args, err := deleteStatement(ctx, id, version)
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "DeleteNote", query)
+	defer func() { done(err) }()
+
+	return execDelete(ctx, s.db, id, version, query, args)
+}
+
+func deleteStatement(ctx context.Context, id uint64, version uint64) (string, []any, error) {
 	if id == 0 {
-		return storage.ErrFieldUnspecified
+		return "", nil, storage.ErrFieldUnspecified
 	}
-	query := `DELETE FROM notes WHERE id = $1`
-	if _, err := s.db.Exec(ctx, query, id); err != nil {
-		return err
+	qr := squirrel.Delete("notes").
+		Where(squirrel.Eq{"id": id}).
+		Where(ownerScope(ctx)).
+		PlaceholderFormat(squirrel.Dollar)
+	if version != 0 {
+		qr = qr.Where(squirrel.Eq{"version": version})
 	}
+	return qr.ToSql()
+}
+
+type execQuerier interface {
+	querier
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
 
+func execDelete(ctx context.Context, db execQuerier, id, version uint64, query string, args []any) error {
+	tag, err := db.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return missingNoteError(ctx, db, id, version)
+	}
 	return nil
 }
 
-func (s *Storage) UpdateNote(ctx context.Context, note models.Note) error {
+// UpdateNote sets the fields of note, zero values included, and bumps its version.
+// A non-zero note.Version makes it fail with ErrVersionConflict when the note has
+// another version.
+func (s *Storage) UpdateNote(ctx context.Context, note models.Note, fields []models.NoteField) (err error) {
+	u, err := updateStatement(ctx, note, fields)
+	if err != nil {
+		return err
+	}
+
+	ctx, done := instrument(ctx, "UpdateNote", u.query)
+	defer func() { done(err) }()
+
+	return pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		op := func() error {
+			_, err := s.execUpdate(ctx, tx, note, u)
+			return err
+		}
+		if u.reactivates {
+			return s.withinQuotas(ctx, tx, op)
+		}
+		return op()
+	})
+}
+
+// noteUpdate is the statement of an update and the changes that follow it.
+type noteUpdate struct {
+	query       string
+	args        []any
+	textChanged bool
+	tagsChanged bool
+	// reactivates is set when the update clears the acknowledgement of the note.
+	reactivates bool
+}
+
+func updateStatement(ctx context.Context, note models.Note, fields []models.NoteField) (noteUpdate, error) {
 	if note.ID == 0 {
-		return storage.ErrFieldUnspecified
+		return noteUpdate{}, storage.ErrFieldUnspecified
 	}
-	if _, err := s.GetNote(ctx, note.ID); errors.Is(err, storage.ErrNotFound) {
-		return storage.ErrNotFound
+	if len(fields) == 0 {
+		return noteUpdate{}, storage.ErrNotEnoughArguments
 	}
-	qr := squirrel.Update("notes")
-
-	fields := map[string]interface{}{
-		"title":       note.Title,
-		"description": note.Description,
-		"date_notify": note.DateNotify,
-		"delay":       note.Delay,
-	}
-	for field, value := range fields {
-		// // Not really good via reflection.
-		// if value != nil &&
-		// 	!reflect.DeepEqual(value, reflect.Zero(reflect.TypeOf(value)).Interface()) {
-		// 	qr = qr.Set(field, value)
-		// }
-		switch v := value.(type) {
-		case string:
-			if v == "" {
-				continue
-			}
-		case time.Time:
-			if v.IsZero() {
-				continue
-			}
-		case time.Duration:
-			if v == 0 {
-				continue
+
+	var u noteUpdate
+	qr := squirrel.Update("notes").Set("version", squirrel.Expr("version + 1"))
+	for _, f := range fields {
+		switch f {
+		case models.FieldTitle:
+			qr = qr.Set("title", note.Title)
+			u.textChanged = true
+		case models.FieldDescription:
+			qr = qr.Set("description", note.Description)
+			u.textChanged = true
+		case models.FieldDateNotify:
+			if note.DateNotify.IsZero() {
+				return noteUpdate{}, fmt.Errorf("%w: %s can't be cleared", storage.ErrInvalidField, f)
 			}
+			// A rescheduled note is due again.
+			qr = qr.Set("date_notify", note.DateNotify).Set("acknowledged_at", nil)
+			u.reactivates = true
+		case models.FieldDelay:
+			qr = qr.Set("delay", note.Delay)
+		case models.FieldTags:
+			u.tagsChanged = true
+		default:
+			return noteUpdate{}, fmt.Errorf("%w: %s", storage.ErrInvalidField, f)
 		}
-		qr = qr.Set(field, value)
 	}
 
-	qr = qr.Where(squirrel.Eq{"id": note.ID}).PlaceholderFormat(squirrel.Dollar)
-	q, args, err := qr.ToSql()
-	if err != nil {
-		log.Println(len(args))
-		if len(args) == 0 {
-			return fmt.Errorf("%w, %w", storage.ErrNotEnoughArguments, err)
+	qr = qr.Where(squirrel.Eq{"id": note.ID}).Where(ownerScope(ctx)).
+		Suffix("RETURNING version").
+		PlaceholderFormat(squirrel.Dollar)
+	if note.Version != 0 {
+		qr = qr.Where(squirrel.Eq{"version": note.Version})
+	}
+
+	var err error
+	u.query, u.args, err = qr.ToSql()
+	return u, err
+}
+
+// execUpdate runs u on note and returns the new version of the note.
+func (s *Storage) execUpdate(ctx context.Context, tx pgx.Tx, note models.Note, u noteUpdate) (uint64, error) {
+	var version uint64
+	if err := tx.QueryRow(ctx, u.query, u.args...).Scan(&version); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, missingNoteError(ctx, tx, note.ID, note.Version)
+		}
+		return 0, err
+	}
+	if u.textChanged {
+		if err := s.updateSearch(ctx, tx, note.ID); err != nil {
+			return 0, err
+		}
+	}
+	if u.tagsChanged {
+		if err := setTags(ctx, tx, note.ID, note.Tags); err != nil {
+			return 0, err
 		}
+	}
+	return version, nil
+}
+
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// missingNoteError tells why a statement on note id affected no rows: the note does
+// not exist for the caller, or it has another version than expected.
+func missingNoteError(ctx context.Context, q querier, id uint64, version uint64) error {
+	if version == 0 {
+		return storage.ErrNotFound
+	}
+	query, args, err := squirrel.Select("version").From("notes").
+		Where(squirrel.Eq{"id": id}).
+		Where(ownerScope(ctx)).
+		PlaceholderFormat(squirrel.Dollar).ToSql()
+	if err != nil {
 		return err
 	}
 
-	if _, err := s.db.Exec(ctx, q, args...); err != nil {
+	var current uint64
+	if err := q.QueryRow(ctx, query, args...).Scan(&current); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.ErrNotFound
+		}
 		return err
 	}
-	return nil
+	return fmt.Errorf("%w: version is %d, not %d", storage.ErrVersionConflict, current, version)
 }
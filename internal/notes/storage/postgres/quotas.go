@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/auth"
+	"notes/internal/pkg/models"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// quotaLockClass is the first key of the advisory locks taken on the quotas of
+// a user, the second is the user id.
+const quotaLockClass = 0x6e6f7465
+
+// CountNotes returns the number of the caller's notes and of those whose reminder is active.
+func (s *Storage) CountNotes(ctx context.Context) (_ models.NoteCounts, err error) {
+	query, args, err := countNotesStatement(ctx)
+	if err != nil {
+		return models.NoteCounts{}, err
+	}
+
+	ctx, done := instrument(ctx, "CountNotes", query)
+	defer func() { done(err) }()
+
+	return countNotes(ctx, s.db, query, args)
+}
+
+func countNotesStatement(ctx context.Context) (string, []any, error) {
+	return squirrel.Select("COUNT(*)", "COUNT(*) FILTER (WHERE acknowledged_at IS NULL)").
+		From("notes").
+		Where(ownerScope(ctx)).
+		PlaceholderFormat(squirrel.Dollar).ToSql()
+}
+
+func countNotes(ctx context.Context, q querier, query string, args []any) (models.NoteCounts, error) {
+	var c models.NoteCounts
+	if err := q.QueryRow(ctx, query, args...).Scan(&c.Notes, &c.Reminders); err != nil {
+		return models.NoteCounts{}, err
+	}
+	return c, nil
+}
+
+// withinQuotas runs op in tx and fails with storage.ErrQuotaExceeded when it
+// took the user of ctx over the quotas. Writes of the same user wait for each
+// other on an advisory lock, so that concurrent ones can't all pass the check.
+// Services and unauthenticated callers are not limited.
+func (s *Storage) withinQuotas(ctx context.Context, tx pgx.Tx, op func() error) error {
+	ownerID, ok := auth.OwnerFromContext(ctx)
+	if !ok || (s.quotas.MaxNotes <= 0 && s.quotas.MaxReminders <= 0) {
+		return op()
+	}
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1, $2)`, quotaLockClass, int32(ownerID)); err != nil {
+		return err
+	}
+	query, args, err := countNotesStatement(ctx)
+	if err != nil {
+		return err
+	}
+	before, err := countNotes(ctx, tx, query, args)
+	if err != nil {
+		return err
+	}
+	if err := op(); err != nil {
+		return err
+	}
+	after, err := countNotes(ctx, tx, query, args)
+	if err != nil {
+		return err
+	}
+
+	// Users already over a lowered quota may still change their notes, as long
+	// as they don't add to them.
+	switch {
+	case s.quotas.MaxNotes > 0 && after.Notes > before.Notes && after.Notes > s.quotas.MaxNotes:
+		return fmt.Errorf("%w: at most %d notes", storage.ErrQuotaExceeded, s.quotas.MaxNotes)
+	case s.quotas.MaxReminders > 0 && after.Reminders > before.Reminders && after.Reminders > s.quotas.MaxReminders:
+		return fmt.Errorf("%w: at most %d active reminders", storage.ErrQuotaExceeded, s.quotas.MaxReminders)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"notes/internal/pkg/models"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// headlineOptions mark the matches of snippets with <b> tags. The text is
+// HTML-escaped before, see escapedText, so snippets are safe to render as HTML.
+const headlineOptions = "StartSel=<b>, StopSel=</b>, MaxWords=30, MinWords=10, MaxFragments=2"
+
+// escapedText is the title and description of a note with &, <, > and " escaped.
+// The text search parser reads the entities as single tokens, which are never
+// highlighted.
+const escapedText = `replace(replace(replace(replace(
+	COALESCE(title, '') || ' ' || COALESCE(description, ''),
+	'&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;')`
+
+// searchVector builds the search vector of a note with the configuration $1.
+const searchVector = `setweight(to_tsvector($1::regconfig, COALESCE(title, '')), 'A') ||
+	setweight(to_tsvector($1::regconfig, COALESCE(description, '')), 'B')`
+
+// updateSearch recomputes the search vectors of notes after their text changed.
+func (s *Storage) updateSearch(ctx context.Context, tx pgx.Tx, ids ...uint64) error {
+	_, err := tx.Exec(ctx, `UPDATE notes SET search = `+searchVector+` WHERE id = ANY($2)`, s.searchLang, ids)
+	return err
+}
+
+// syncSearchLanguage rebuilds the search vectors of all notes when they were
+// built with another configuration than lang. The migrations build them with
+// english.
+func syncSearchLanguage(ctx context.Context, db *pgxpool.Pool, lang string) error {
+	// The table is missing when the migrations are older than it, the vectors
+	// are english then.
+	var migrated bool
+	if err := db.QueryRow(ctx, `SELECT to_regclass('search_settings') IS NOT NULL`).Scan(&migrated); err != nil {
+		return err
+	}
+	if !migrated {
+		return nil
+	}
+
+	return pgx.BeginFunc(ctx, db, func(tx pgx.Tx) error {
+		// The lock makes other instances starting with lang wait for the rebuild.
+		var built string
+		if err := tx.QueryRow(ctx, `SELECT language FROM search_settings FOR UPDATE`).Scan(&built); err != nil {
+			return err
+		}
+		if built == lang {
+			return nil
+		}
+
+		if _, err := tx.Exec(ctx, `UPDATE notes SET search = `+searchVector, lang); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, `UPDATE search_settings SET language = $1`, lang)
+		return err
+	})
+}
+
+// Search returns the caller's notes matching q.Query ordered by rank, with HTML
+// snippets highlighting the matches.
+// The query accepts the websearch syntax: quoted phrases, "or" and "-" for negation.
+func (s *Storage) Search(ctx context.Context, q models.SearchQuery) (_ models.SearchPage, err error) {
+	query, args, err := squirrel.Select(noteColumns...).
+		Column("ts_rank(search, query)").
+		Column(squirrel.Expr(
+			"ts_headline(?::regconfig, "+escapedText+", query, ?)",
+			s.searchLang, headlineOptions)).
+		From("notes").
+		JoinClause("CROSS JOIN websearch_to_tsquery(?::regconfig, ?) query", s.searchLang, q.Query).
+		Where("search @@ query").
+		Where(ownerScope(ctx)).
+		OrderBy("ts_rank(search, query) DESC", "id").
+		// One extra row tells whether there is a next page.
+		Limit(uint64(q.Limit) + 1).
+		Offset(uint64(q.Offset)).
+		PlaceholderFormat(squirrel.Dollar).ToSql()
+	if err != nil {
+		return models.SearchPage{}, err
+	}
+
+	ctx, done := instrument(ctx, "Search", query)
+	defer func() { done(err) }()
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return models.SearchPage{}, err
+	}
+	defer rows.Close()
+
+	page := models.SearchPage{Results: make([]models.SearchResult, 0, q.Limit)}
+	for rows.Next() {
+		var r models.SearchResult
+		n := &r.Note
+		if err = rows.Scan(&n.ID, &n.Title, &n.Description, &n.DateAdded, &n.DateNotify, &n.Delay,
+			&n.OwnerID, &n.Version, &n.AcknowledgedAt, &n.Tags, &r.Rank, &r.Snippet); err != nil {
+			return models.SearchPage{}, err
+		}
+		page.Results = append(page.Results, r)
+	}
+	if err = rows.Err(); err != nil {
+		return models.SearchPage{}, err
+	}
+
+	if len(page.Results) > q.Limit {
+		page.Results = page.Results[:q.Limit]
+		page.NextOffset = q.Offset + q.Limit
+	}
+	return page, nil
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"notes/internal/pkg/models"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// setTags replaces the tags of a note, creating tags that don't exist yet.
+func setTags(ctx context.Context, tx pgx.Tx, noteID uint64, tags []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM note_tags WHERE note_id = $1`, noteID); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO tags(name) SELECT unnest($1::text[]) ON CONFLICT (name) DO NOTHING`, tags); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx,
+		`INSERT INTO note_tags(note_id, tag_id) SELECT $1, id FROM tags WHERE name = ANY($2)`, noteID, tags)
+	return err
+}
+
+// tagsFilter matches notes that have any of tags, or all of them if matchAll is set.
+func tagsFilter(tags []string, matchAll bool) squirrel.Sqlizer {
+	if matchAll {
+		return squirrel.Expr(`(SELECT COUNT(*) FROM note_tags nt JOIN tags t ON t.id = nt.tag_id
+			WHERE nt.note_id = notes.id AND t.name = ANY(?)) = ?`, tags, len(tags))
+	}
+	return squirrel.Expr(`EXISTS (SELECT 1 FROM note_tags nt JOIN tags t ON t.id = nt.tag_id
+		WHERE nt.note_id = notes.id AND t.name = ANY(?))`, tags)
+}
+
+// ListTags returns the tags used by the caller's notes with the number of notes for each.
+func (s *Storage) ListTags(ctx context.Context) (_ []models.TagCount, err error) {
+	query, args, err := squirrel.Select("t.name", "COUNT(notes.id)").
+		From("tags t").
+		Join("note_tags nt ON nt.tag_id = t.id").
+		Join("notes ON notes.id = nt.note_id").
+		Where(ownerScope(ctx)).
+		GroupBy("t.name").
+		OrderBy("t.name").
+		PlaceholderFormat(squirrel.Dollar).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := instrument(ctx, "ListTags", query)
+	defer func() { done(err) }()
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]models.TagCount, 0, 16)
+	for rows.Next() {
+		var t models.TagCount
+		if err = rows.Scan(&t.Name, &t.Count); err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+	return tags, rows.Err()
+}
+
+// addTags tags new notes, tags[i] being the tags of the note ids[i].
+func addTags(ctx context.Context, tx pgx.Tx, ids []uint64, tags [][]string) error {
+	var (
+		noteIDs []uint64
+		names   []string
+	)
+	for i, t := range tags {
+		for _, name := range t {
+			noteIDs = append(noteIDs, ids[i])
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO tags(name) SELECT DISTINCT unnest($1::text[]) ON CONFLICT (name) DO NOTHING`, names); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx, `INSERT INTO note_tags(note_id, tag_id)
+		SELECT DISTINCT n.note_id, t.id FROM unnest($1::bigint[], $2::text[]) AS n(note_id, name)
+		JOIN tags t ON t.name = n.name`, noteIDs, names)
+	return err
+}
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *Storage) CreateUser(ctx context.Context, name string) (_ models.User, err error) {
+	query := `INSERT INTO users(name) VALUES ($1) RETURNING id, name, created_at`
+
+	ctx, done := instrument(ctx, "CreateUser", query)
+	defer func() { done(err) }()
+
+	var u models.User
+	if err = s.db.QueryRow(ctx, query, name).Scan(&u.ID, &u.Name, &u.CreatedAt); err != nil {
+		return models.User{}, err
+	}
+	return u, nil
+}
+
+func (s *Storage) GetUser(ctx context.Context, id uint64) (_ models.User, err error) {
+	query := `SELECT id, name, created_at FROM users WHERE id = $1`
+
+	ctx, done := instrument(ctx, "GetUser", query)
+	defer func() { done(err) }()
+
+	var u models.User
+	if err = s.db.QueryRow(ctx, query, id).Scan(&u.ID, &u.Name, &u.CreatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.User{}, storage.ErrNotFound
+		}
+		return models.User{}, err
+	}
+	return u, nil
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"errors"
+	"fmt"
 )
 
 var (
@@ -10,4 +11,25 @@ var (
 	ErrDatabaseNotExists  = errors.New("database don't exist")
 	ErrNotFound           = errors.New("entity not found")
 	ErrNotEnoughArguments = errors.New("not enough arguments in call")
+	ErrMigrationVersion   = errors.New("unexpected migration version")
+	ErrInvalidPageToken   = errors.New("invalid page token")
+	ErrInvalidSort        = errors.New("invalid sort field")
+	ErrVersionConflict    = errors.New("note was changed concurrently")
+	ErrInvalidField       = errors.New("invalid field")
+	ErrQuotaExceeded      = errors.New("quota exceeded")
+	ErrUnknownOwner       = errors.New("owner of the note is not a user")
 )
+
+// BatchError is returned by atomic batches, which stop at the first failing item.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("batch item %d: %s", e.Index, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
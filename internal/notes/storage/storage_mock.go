@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"notes/internal/notes/idempotency"
 	"notes/internal/pkg/models"
 	"time"
 
@@ -12,19 +13,19 @@ type MockStorage struct {
 	mock.Mock
 }
 
-func (s *MockStorage) CreateNote(_ context.Context, n models.Note) error {
+func (s *MockStorage) CreateNote(_ context.Context, n models.Note) (models.Note, error) {
 	ctx := context.Background()
 	args := s.Called(ctx, n)
 
-	return args.Error(0)
+	return args.Get(0).(models.Note), args.Error(1)
 }
 
-func (s *MockStorage) GetNotes(_ context.Context, t time.Duration) ([]models.Note, error) {
+func (s *MockStorage) GetNotes(_ context.Context, f models.NotesFilter) (models.NotesPage, error) {
 	ctx := context.Background()
 
-	args := s.Called(ctx, t)
+	args := s.Called(ctx, f)
 
-	return args.Get(0).([]models.Note), args.Error(1)
+	return args.Get(0).(models.NotesPage), args.Error(1)
 }
 
 func (s *MockStorage) GetNote(_ context.Context, id uint64) (models.Note, error) {
@@ -35,18 +36,130 @@ func (s *MockStorage) GetNote(_ context.Context, id uint64) (models.Note, error)
 	return args.Get(0).(models.Note), args.Error(1)
 }
 
-func (s *MockStorage) DeleteNote(_ context.Context, id uint64) error {
+func (s *MockStorage) DeleteNote(_ context.Context, id uint64, version uint64) error {
+	ctx := context.Background()
+
+	args := s.Called(ctx, id, version)
+
+	return args.Error(0)
+}
+
+func (s *MockStorage) UpdateNote(_ context.Context, n models.Note, fields []models.NoteField) error {
+	ctx := context.Background()
+
+	args := s.Called(ctx, n, fields)
+
+	return args.Error(0)
+}
+
+func (s *MockStorage) RefreshNote(_ context.Context, id uint64, maxDelay time.Duration) (models.Note, bool, error) {
+	ctx := context.Background()
+
+	args := s.Called(ctx, id, maxDelay)
+
+	return args.Get(0).(models.Note), args.Bool(1), args.Error(2)
+}
+
+func (s *MockStorage) SnoozeNote(_ context.Context, id uint64, until time.Time) (models.Note, error) {
+	ctx := context.Background()
+
+	args := s.Called(ctx, id, until)
+
+	return args.Get(0).(models.Note), args.Error(1)
+}
+
+func (s *MockStorage) AcknowledgeNote(_ context.Context, id uint64) (models.Note, error) {
 	ctx := context.Background()
 
 	args := s.Called(ctx, id)
 
+	return args.Get(0).(models.Note), args.Error(1)
+}
+
+func (s *MockStorage) ListTags(_ context.Context) ([]models.TagCount, error) {
+	ctx := context.Background()
+
+	args := s.Called(ctx)
+
+	return args.Get(0).([]models.TagCount), args.Error(1)
+}
+
+func (s *MockStorage) CountNotes(_ context.Context) (models.NoteCounts, error) {
+	ctx := context.Background()
+
+	args := s.Called(ctx)
+
+	return args.Get(0).(models.NoteCounts), args.Error(1)
+}
+
+func (s *MockStorage) Search(_ context.Context, q models.SearchQuery) (models.SearchPage, error) {
+	ctx := context.Background()
+
+	args := s.Called(ctx, q)
+
+	return args.Get(0).(models.SearchPage), args.Error(1)
+}
+
+func (s *MockStorage) ReserveIdempotencyKey(_ context.Context, r idempotency.Record,
+	ttl time.Duration,
+) (idempotency.Record, bool, error) {
+	ctx := context.Background()
+
+	args := s.Called(ctx, r, ttl)
+
+	return args.Get(0).(idempotency.Record), args.Bool(1), args.Error(2)
+}
+
+func (s *MockStorage) SaveIdempotencyKey(_ context.Context, r idempotency.Record) error {
+	ctx := context.Background()
+
+	args := s.Called(ctx, r)
+
 	return args.Error(0)
 }
 
-func (s *MockStorage) UpdateNote(_ context.Context, n models.Note) error {
+func (s *MockStorage) ReleaseIdempotencyKey(_ context.Context, r idempotency.Record) error {
 	ctx := context.Background()
 
-	args := s.Called(ctx, n)
+	args := s.Called(ctx, r)
 
 	return args.Error(0)
 }
+
+func (s *MockStorage) PurgeIdempotencyKeys(_ context.Context) (int64, error) {
+	ctx := context.Background()
+
+	args := s.Called(ctx)
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (s *MockStorage) CreateNotes(_ context.Context, notes []models.Note,
+	mode models.BatchMode,
+) ([]models.BatchResult, error) {
+	ctx := context.Background()
+
+	args := s.Called(ctx, notes, mode)
+
+	return args.Get(0).([]models.BatchResult), args.Error(1)
+}
+
+func (s *MockStorage) UpdateNotes(_ context.Context, updates []models.NoteUpdate,
+	mode models.BatchMode,
+) ([]models.BatchResult, error) {
+	ctx := context.Background()
+
+	args := s.Called(ctx, updates, mode)
+
+	return args.Get(0).([]models.BatchResult), args.Error(1)
+}
+
+func (s *MockStorage) DeleteNotes(_ context.Context, refs []models.NoteRef,
+	mode models.BatchMode,
+) ([]models.BatchResult, error) {
+	ctx := context.Background()
+
+	args := s.Called(ctx, refs, mode)
+
+	return args.Get(0).([]models.BatchResult), args.Error(1)
+}
@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"notes/internal/pkg/config"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// HeaderAPIKey is the REST header and gRPC metadata key carrying an API key.
+	HeaderAPIKey = "X-Api-Key"
+	// HeaderAuthorization carries "Bearer <jwt>" or "ApiKey <key>".
+	HeaderAuthorization = "Authorization"
+
+	schemeBearer = "bearer"
+	schemeAPIKey = "apikey"
+
+	apiKeyPrefix = "nk_"
+)
+
+var (
+	ErrUnauthenticated = errors.New("unauthenticated")
+	ErrInvalidToken    = errors.New("invalid token")
+	ErrInvalidAPIKey   = errors.New("invalid api key")
+)
+
+// KeyStore looks up the owner of a hashed API key.
+type KeyStore interface {
+	PrincipalByAPIKey(ctx context.Context, keyHash string) (Principal, error)
+}
+
+// Credentials are the secrets presented by a caller.
+type Credentials struct {
+	APIKey      string
+	BearerToken string
+}
+
+// ParseCredentials reads credentials from the Authorization and X-Api-Key values.
+func ParseCredentials(authorization, apiKey string) Credentials {
+	c := Credentials{APIKey: strings.TrimSpace(apiKey)}
+
+	scheme, value, ok := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !ok {
+		return c
+	}
+	switch strings.ToLower(scheme) {
+	case schemeBearer:
+		c.BearerToken = strings.TrimSpace(value)
+	case schemeAPIKey:
+		c.APIKey = strings.TrimSpace(value)
+	}
+	return c
+}
+
+type claims struct {
+	Name string `json:"name,omitempty"`
+	Kind Kind   `json:"kind"`
+	jwt.RegisteredClaims
+}
+
+type Authenticator struct {
+	keys   KeyStore
+	secret []byte
+	issuer string
+}
+
+func New(cfg config.Auth, keys KeyStore) *Authenticator {
+	return &Authenticator{
+		keys:   keys,
+		secret: []byte(cfg.JWTSecret),
+		issuer: cfg.Issuer,
+	}
+}
+
+// Authenticate resolves the principal behind c. A bearer token takes precedence over an API key.
+func (a *Authenticator) Authenticate(ctx context.Context, c Credentials) (Principal, error) {
+	switch {
+	case c.BearerToken != "":
+		return a.verifyToken(c.BearerToken)
+	case c.APIKey != "":
+		p, err := a.keys.PrincipalByAPIKey(ctx, HashAPIKey(c.APIKey))
+		if err != nil {
+			return Principal{}, fmt.Errorf("%w: %w", ErrInvalidAPIKey, err)
+		}
+		return p, nil
+	}
+	return Principal{}, ErrUnauthenticated
+}
+
+// IssueToken signs a JWT for p that expires after ttl.
+func (a *Authenticator) IssueToken(p Principal, ttl time.Duration) (string, error) {
+	now := time.Now()
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Name: p.Name,
+		Kind: p.Kind,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    a.issuer,
+			Subject:   strconv.FormatUint(p.ID, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	})
+	return t.SignedString(a.secret)
+}
+
+func (a *Authenticator) verifyToken(token string) (Principal, error) {
+	if len(a.secret) == 0 {
+		return Principal{}, fmt.Errorf("%w: tokens are not accepted", ErrInvalidToken)
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()})}
+	if a.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(a.issuer))
+	}
+
+	var c claims
+	_, err := jwt.ParseWithClaims(token, &c, func(*jwt.Token) (interface{}, error) {
+		return a.secret, nil
+	}, opts...)
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	id, err := strconv.ParseUint(c.Subject, 10, 64)
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: bad subject", ErrInvalidToken)
+	}
+	if c.Kind != KindUser && c.Kind != KindService {
+		return Principal{}, fmt.Errorf("%w: bad kind", ErrInvalidToken)
+	}
+	return Principal{ID: id, Name: c.Name, Kind: c.Kind}, nil
+}
+
+// GenerateAPIKey returns a new random API key and the hash to store for it.
+func GenerateAPIKey() (key, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	key = apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return key, HashAPIKey(key), nil
+}
+
+// HashAPIKey returns the form of key stored in the database.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
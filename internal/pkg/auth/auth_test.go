@@ -0,0 +1,80 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"notes/internal/pkg/auth"
+	"notes/internal/pkg/config"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type keyStore map[string]auth.Principal
+
+func (ks keyStore) PrincipalByAPIKey(_ context.Context, hash string) (auth.Principal, error) {
+	p, ok := ks[hash]
+	if !ok {
+		return auth.Principal{}, errors.New("not found")
+	}
+	return p, nil
+}
+
+func TestAuthenticator(t *testing.T) {
+	key, hash, err := auth.GenerateAPIKey()
+	require.NoError(t, err)
+
+	user := auth.Principal{ID: 7, Name: "bob", Kind: auth.KindUser}
+	a := auth.New(config.Auth{JWTSecret: "secret", Issuer: "notes"}, keyStore{hash: user})
+
+	ctx := context.Background()
+
+	t.Run("Test API Key", func(t *testing.T) {
+		p, err := a.Authenticate(ctx, auth.ParseCredentials("", key))
+		assert.NoError(t, err)
+		assert.Equal(t, user, p)
+
+		p, err = a.Authenticate(ctx, auth.ParseCredentials("ApiKey "+key, ""))
+		assert.NoError(t, err)
+		assert.Equal(t, user, p)
+
+		_, err = a.Authenticate(ctx, auth.ParseCredentials("", "nk_wrong"))
+		assert.ErrorIs(t, err, auth.ErrInvalidAPIKey)
+	})
+
+	t.Run("Test Token", func(t *testing.T) {
+		svc := auth.Principal{Name: "publisher", Kind: auth.KindService}
+		token, err := a.IssueToken(svc, time.Minute)
+		require.NoError(t, err)
+
+		p, err := a.Authenticate(ctx, auth.ParseCredentials("Bearer "+token, ""))
+		assert.NoError(t, err)
+		assert.Equal(t, svc, p)
+		assert.True(t, p.IsService())
+
+		other := auth.New(config.Auth{JWTSecret: "other"}, nil)
+		_, err = other.Authenticate(ctx, auth.ParseCredentials("Bearer "+token, ""))
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+
+		expired, err := a.IssueToken(svc, -time.Minute)
+		require.NoError(t, err)
+		_, err = a.Authenticate(ctx, auth.ParseCredentials("Bearer "+expired, ""))
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("Test No Credentials", func(t *testing.T) {
+		_, err := a.Authenticate(ctx, auth.ParseCredentials("", ""))
+		assert.ErrorIs(t, err, auth.ErrUnauthenticated)
+	})
+
+	t.Run("Test Context", func(t *testing.T) {
+		_, ok := auth.FromContext(ctx)
+		assert.False(t, ok)
+
+		p, ok := auth.FromContext(auth.NewContext(ctx, user))
+		assert.True(t, ok)
+		assert.Equal(t, user, p)
+	})
+}
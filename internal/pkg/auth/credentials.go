@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// Empty reports whether no secret is set.
+func (c Credentials) Empty() bool {
+	return c.APIKey == "" && c.BearerToken == ""
+}
+
+// SetHeader adds c to the headers of an outgoing REST request.
+func (c Credentials) SetHeader(h http.Header) {
+	if c.BearerToken != "" {
+		h.Set(HeaderAuthorization, "Bearer "+c.BearerToken)
+		return
+	}
+	if c.APIKey != "" {
+		h.Set(HeaderAPIKey, c.APIKey)
+	}
+}
+
+// PerRPC returns c as gRPC call credentials. When secure is set, they are only
+// sent over connections with transport security.
+func (c Credentials) PerRPC(secure bool) credentials.PerRPCCredentials {
+	return perRPC{Credentials: c, secure: secure}
+}
+
+type perRPC struct {
+	Credentials
+	secure bool
+}
+
+func (c perRPC) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	if c.BearerToken != "" {
+		return map[string]string{strings.ToLower(HeaderAuthorization): "Bearer " + c.BearerToken}, nil
+	}
+	return map[string]string{strings.ToLower(HeaderAPIKey): c.APIKey}, nil
+}
+
+// RequireTransportSecurity keeps the secrets off plaintext connections when the
+// client dials with TLS.
+func (c perRPC) RequireTransportSecurity() bool {
+	return c.secure
+}
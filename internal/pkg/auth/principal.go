@@ -0,0 +1,46 @@
+package auth
+
+import "context"
+
+type Kind string
+
+const (
+	// KindUser is a person whose access is limited to their own notes.
+	KindUser Kind = "user"
+	// KindService is an internal service such as the publisher.
+	KindService Kind = "service"
+)
+
+// Principal is the authenticated caller of the notes API.
+type Principal struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+	Kind Kind   `json:"kind"`
+}
+
+func (p Principal) IsService() bool {
+	return p.Kind == KindService
+}
+
+type principalKey struct{}
+
+// NewContext returns ctx carrying p.
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal stored in ctx by the auth middleware or interceptor.
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// OwnerFromContext returns the ID of the user whose notes the caller may access.
+// It reports false for services and unauthenticated callers, which are not limited to one owner.
+func OwnerFromContext(ctx context.Context) (uint64, bool) {
+	p, ok := FromContext(ctx)
+	if !ok || p.Kind != KindUser {
+		return 0, false
+	}
+	return p.ID, true
+}
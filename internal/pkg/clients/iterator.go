@@ -0,0 +1,67 @@
+package clients
+
+import "notes/internal/pkg/models"
+
+// PageFetcher returns the page of notes starting at pageToken.
+type PageFetcher func(pageToken string) (models.NotesPage, error)
+
+// NotesIterator walks every page of a notes listing:
+//
+//	it := client.Notes(ctx, filter)
+//	for it.Next() {
+//		note := it.Note()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type NotesIterator struct {
+	fetch PageFetcher
+	notes []models.Note
+	note  models.Note
+	token string
+	last  bool
+	err   error
+}
+
+// NewNotesIterator starts at pageToken, "" is the first page.
+func NewNotesIterator(pageToken string, fetch PageFetcher) *NotesIterator {
+	return &NotesIterator{fetch: fetch, token: pageToken}
+}
+
+// Next advances to the next note, fetching the next page when needed.
+// It returns false when the listing is exhausted or a fetch failed.
+func (it *NotesIterator) Next() bool {
+	for len(it.notes) == 0 {
+		if it.last || it.err != nil {
+			return false
+		}
+		page, err := it.fetch(it.token)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.notes = page.Notes
+		it.token = page.NextPageToken
+		it.last = page.NextPageToken == ""
+	}
+	it.note, it.notes = it.notes[0], it.notes[1:]
+	return true
+}
+
+// Note returns the current note.
+func (it *NotesIterator) Note() models.Note {
+	return it.note
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *NotesIterator) Err() error {
+	return it.err
+}
+
+// All drains the iterator.
+func (it *NotesIterator) All() ([]models.Note, error) {
+	notes := make([]models.Note, 0, len(it.notes))
+	for it.Next() {
+		notes = append(notes, it.Note())
+	}
+	return notes, it.Err()
+}
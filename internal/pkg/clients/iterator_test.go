@@ -0,0 +1,46 @@
+package clients_test
+
+import (
+	"errors"
+	"notes/internal/pkg/clients"
+	"notes/internal/pkg/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotesIterator(t *testing.T) {
+	pages := map[string]models.NotesPage{
+		"":  {Notes: []models.Note{{ID: 1}, {ID: 2}}, NextPageToken: "a"},
+		"a": {Notes: []models.Note{}, NextPageToken: "b"},
+		"b": {Notes: []models.Note{{ID: 3}}},
+	}
+
+	t.Run("Test All Pages", func(t *testing.T) {
+		var tokens []string
+		it := clients.NewNotesIterator("", func(token string) (models.NotesPage, error) {
+			tokens = append(tokens, token)
+			return pages[token], nil
+		})
+
+		notes, err := it.All()
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Note{{ID: 1}, {ID: 2}, {ID: 3}}, notes)
+		assert.Equal(t, []string{"", "a", "b"}, tokens)
+		assert.False(t, it.Next())
+	})
+
+	t.Run("Test Fetch Error", func(t *testing.T) {
+		errFetch := errors.New("fetch failed")
+		it := clients.NewNotesIterator("", func(token string) (models.NotesPage, error) {
+			if token == "a" {
+				return models.NotesPage{}, errFetch
+			}
+			return pages[token], nil
+		})
+
+		notes, err := it.All()
+		assert.ErrorIs(t, err, errFetch)
+		assert.Len(t, notes, 2)
+	})
+}
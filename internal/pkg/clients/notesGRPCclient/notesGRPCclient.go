@@ -2,55 +2,209 @@ package notesgrpcclient
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"notes/internal/notes/idempotency"
 	"notes/internal/notes/server/grpcserver"
 	"notes/internal/notes/server/grpcserver/pb"
+	"notes/internal/pkg/auth"
+	"notes/internal/pkg/clients"
 	"notes/internal/pkg/config"
 	"notes/internal/pkg/models"
+	"notes/internal/pkg/tlsconfig"
+	"notes/internal/pkg/tracing"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+var ErrNotServing = errors.New("notes API is not serving")
+
+const (
+	// DefaultCallTimeout bounds the unary calls made without a shorter deadline.
+	DefaultCallTimeout = time.Second * 10
+	// DefaultFetchWindow is how far ahead Fetch looks for due notes.
+	DefaultFetchWindow = time.Minute * 5
+)
+
+// DefaultKeepalive pings idle connections often enough to notice dead servers and
+// keep proxies from dropping them, within the server's keepalive enforcement policy.
+var DefaultKeepalive = keepalive.ClientParameters{
+	Time:                time.Second * 30,
+	Timeout:             time.Second * 10,
+	PermitWithoutStream: true,
+}
+
 type Client struct {
-	cl   pb.NotesClient
-	conn *grpc.ClientConn
+	cl          pb.NotesClient
+	conn        *grpc.ClientConn
+	fetchWindow time.Duration
+}
+
+type options struct {
+	creds       auth.Credentials
+	retry       RetryPolicy
+	callTimeout time.Duration
+	keepalive   keepalive.ClientParameters
+	addrs       []string
+	fetchWindow time.Duration
+	dialOpts    []grpc.DialOption
+}
+
+type Option func(*options)
+
+// WithAPIKey authenticates calls with an API key.
+func WithAPIKey(key string) Option {
+	return func(o *options) {
+		o.creds.APIKey = key
+	}
+}
+
+// WithBearerToken authenticates calls with a signed JWT.
+func WithBearerToken(token string) Option {
+	return func(o *options) {
+		o.creds.BearerToken = token
+	}
 }
 
-func New(cfg config.GRPCServer) (*Client, error) {
-	conn, err := grpc.Dial(cfg.Host+cfg.Port, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// WithRetryPolicy replaces DefaultRetryPolicy, a zero policy disables retries.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = p
+	}
+}
+
+// WithCallTimeout replaces DefaultCallTimeout, zero leaves calls to their context.
+func WithCallTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.callTimeout = d
+	}
+}
+
+// WithKeepalive replaces DefaultKeepalive.
+func WithKeepalive(p keepalive.ClientParameters) Option {
+	return func(o *options) {
+		o.keepalive = p
+	}
+}
+
+// WithAddresses balances calls round-robin over addrs instead of the host and
+// port of the config. With TLS, the config should set the server name.
+func WithAddresses(addrs ...string) Option {
+	return func(o *options) {
+		o.addrs = addrs
+	}
+}
+
+// WithFetchWindow replaces DefaultFetchWindow.
+func WithFetchWindow(d time.Duration) Option {
+	return func(o *options) {
+		o.fetchWindow = d
+	}
+}
+
+// WithDialOptions adds opts to the options the client dials with.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOpts = append(o.dialOpts, opts...)
+	}
+}
+
+func New(cfg config.GRPCServer, opts ...Option) (*Client, error) {
+	o := options{
+		retry:       DefaultRetryPolicy,
+		callTimeout: DefaultCallTimeout,
+		keepalive:   DefaultKeepalive,
+		fetchWindow: DefaultFetchWindow,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	creds, err := tlsconfig.GRPCCredentials(cfg.TLS)
 	if err != nil {
 		return nil, err
 	}
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithUnaryInterceptor(tracing.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(tracing.StreamClientInterceptor()),
+		grpc.WithDefaultServiceConfig(buildServiceConfig(o.retry, o.callTimeout)),
+		grpc.WithKeepaliveParams(o.keepalive),
+	}
+	if !o.creds.Empty() {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(o.creds.PerRPC(cfg.TLS.Enabled)))
+	}
 
-	return &Client{pb.NewNotesClient(conn), conn}, err
+	target := cfg.Host + cfg.Port
+	if len(o.addrs) > 0 {
+		r := manual.NewBuilderWithScheme("notes")
+		state := resolver.State{}
+		for _, a := range o.addrs {
+			state.Addresses = append(state.Addresses, resolver.Address{Addr: a})
+		}
+		r.InitialState(state)
+		dialOpts = append(dialOpts, grpc.WithResolvers(r))
+		target = r.Scheme() + ":///notes"
+	}
+	dialOpts = append(dialOpts, o.dialOpts...)
+
+	conn, err := grpc.Dial(target, dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{cl: pb.NewNotesClient(conn), conn: conn, fetchWindow: o.fetchWindow}, nil
 }
 
+// Fetch returns every note due within the fetch window.
 func (c *Client) Fetch(ctx context.Context) ([]models.Note, error) {
-	notes, err := c.GetNotes(ctx, time.Minute*5)
+	return c.Notes(ctx, models.NotesFilter{Interval: c.fetchWindow}).All()
+}
+
+// Check reports whether the notes API answers its health service with SERVING.
+func (c *Client) Check(ctx context.Context) error {
+	res, err := healthpb.NewHealthClient(c.conn).Check(ctx, &healthpb.HealthCheckRequest{
+		Service: pb.Notes_ServiceDesc.ServiceName,
+	})
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return notes, nil
+	if res.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("%w: %s", ErrNotServing, res.Status)
+	}
+	return nil
 }
 
-func (c *Client) Close() {
-	c.conn.Close()
+// Close closes the connection, calls in flight fail with codes.Canceled.
+func (c *Client) Close() error {
+	return c.conn.Close()
 }
 
+// UpdateNote updates the fields of note that are set. It fails with codes.Aborted
+// when note.Version is set and the note has another version.
 func (c *Client) UpdateNote(ctx context.Context, note models.Note) error {
-	v := ctx.Value("refreshed")
-	vv, ok := v.(bool)
-	if ok && vv {
-		ctx = metadata.NewOutgoingContext(ctx, metadata.New(map[string]string{
-			"refreshed": "true",
-		}))
-	}
+	return c.UpdateNoteFields(ctx, note)
+}
 
+// UpdateNoteFields sets only the given fields of note, clearing those that are zero.
+// Without fields it behaves like UpdateNote.
+func (c *Client) UpdateNoteFields(ctx context.Context, note models.Note, fields ...models.NoteField) error {
 	res, err := c.cl.UpdateNote(ctx, &pb.UpdateNoteRequest{
-		Note: grpcserver.ToPBNote(note),
+		Note:            grpcserver.ToPBNote(note),
+		ExpectedVersion: note.Version,
+		UpdateMask:      grpcserver.ToPBFieldMask(fields),
 	})
 	if err != nil {
 		return err
@@ -59,22 +213,266 @@ func (c *Client) UpdateNote(ctx context.Context, note models.Note) error {
 	return nil
 }
 
-func (c *Client) CreateNote(ctx context.Context, note models.Note) error {
-	n := grpcserver.ToPBNote(note)
-	_, err := c.cl.CreateNote(ctx, &pb.CreateNoteRequest{
-		Note: n,
-	})
+// DeleteNote deletes the note if it has the version, or any version when it is zero.
+func (c *Client) DeleteNote(ctx context.Context, id uint64, version uint64) error {
+	_, err := c.cl.DeleteNote(ctx, &pb.DeleteNoteRequest{ID: id, ExpectedVersion: version})
 	return err
 }
 
-func (c *Client) GetNotes(ctx context.Context, interval time.Duration) ([]models.Note, error) {
+// RefreshNote reschedules a due note after its delay and multiplies the delay by ten.
+// deleted is true when the note was deleted because its delay grew too long.
+func (c *Client) RefreshNote(ctx context.Context, id uint64) (_ models.Note, deleted bool, err error) {
+	res, err := c.cl.RefreshNote(ctx, &pb.RefreshNoteRequest{ID: id})
+	if err != nil {
+		return models.Note{}, false, err
+	}
+	return grpcserver.ToNote(res.GetNote()), res.GetDeleted(), nil
+}
+
+// SnoozeNote postpones the reminder of a note until the given time.
+func (c *Client) SnoozeNote(ctx context.Context, id uint64, until time.Time) (models.Note, error) {
+	return c.snoozeNote(ctx, &pb.SnoozeNoteRequest{
+		ID:     id,
+		Snooze: &pb.SnoozeNoteRequest_Until{Until: timestamppb.New(until)},
+	})
+}
+
+// SnoozeNoteFor postpones the reminder of a note by d from now.
+func (c *Client) SnoozeNoteFor(ctx context.Context, id uint64, d time.Duration) (models.Note, error) {
+	return c.snoozeNote(ctx, &pb.SnoozeNoteRequest{
+		ID:     id,
+		Snooze: &pb.SnoozeNoteRequest_Duration{Duration: durationpb.New(d)},
+	})
+}
+
+func (c *Client) snoozeNote(ctx context.Context, req *pb.SnoozeNoteRequest) (models.Note, error) {
+	res, err := c.cl.SnoozeNote(ctx, req)
+	if err != nil {
+		return models.Note{}, err
+	}
+	return grpcserver.ToNote(res.GetNote()), nil
+}
+
+// AcknowledgeNote stops the reminders of a note until it is snoozed or rescheduled.
+func (c *Client) AcknowledgeNote(ctx context.Context, id uint64) (models.Note, error) {
+	res, err := c.cl.AcknowledgeNote(ctx, &pb.AcknowledgeNoteRequest{ID: id})
+	if err != nil {
+		return models.Note{}, err
+	}
+	return grpcserver.ToNote(res.GetNote()), nil
+}
+
+// WithIdempotencyKey returns ctx that makes the mutating calls made with it run
+// once on the server. Retries of a call must use the same key.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, strings.ToLower(idempotency.HeaderKey), key)
+}
+
+// CreateNote returns the created note. It can be retried safely with a context
+// from WithIdempotencyKey.
+func (c *Client) CreateNote(ctx context.Context, note models.Note) (models.Note, error) {
+	res, err := c.cl.CreateNote(ctx, &pb.CreateNoteRequest{
+		Note: grpcserver.ToPBNote(note),
+	})
+	if err != nil {
+		return models.Note{}, err
+	}
+	return grpcserver.ToNote(res.GetNote()), nil
+}
+
+// CreateNotes creates notes in one transaction. Failed items of partial batches
+// have a status error in their result.
+func (c *Client) CreateNotes(ctx context.Context, notes []models.Note,
+	mode models.BatchMode,
+) ([]models.BatchResult, error) {
+	return c.createNotes(ctx, notes, mode, false)
+}
+
+// ImportNotes creates notes like CreateNotes, but they keep their delay and a
+// notify date in the future, such as those of exported notes.
+func (c *Client) ImportNotes(ctx context.Context, notes []models.Note,
+	mode models.BatchMode,
+) ([]models.BatchResult, error) {
+	return c.createNotes(ctx, notes, mode, true)
+}
+
+func (c *Client) createNotes(ctx context.Context, notes []models.Note,
+	mode models.BatchMode, keepSchedule bool,
+) ([]models.BatchResult, error) {
+	req := &pb.BatchCreateRequest{Mode: grpcserver.ToPBBatchMode(mode), KeepSchedule: keepSchedule}
+	for _, n := range notes {
+		req.Notes = append(req.Notes, grpcserver.ToPBNote(n))
+	}
+	res, err := c.cl.BatchCreate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return grpcserver.ToBatchResults(res.GetResults()), nil
+}
+
+// UpdateNotes applies updates in one transaction, see CreateNotes.
+func (c *Client) UpdateNotes(ctx context.Context, updates []models.NoteUpdate,
+	mode models.BatchMode,
+) ([]models.BatchResult, error) {
+	req := &pb.BatchUpdateRequest{Mode: grpcserver.ToPBBatchMode(mode)}
+	for _, u := range updates {
+		req.Updates = append(req.Updates, &pb.NoteUpdate{
+			Note:            grpcserver.ToPBNote(u.Note),
+			ExpectedVersion: u.Note.Version,
+			UpdateMask:      grpcserver.ToPBFieldMask(u.Fields),
+		})
+	}
+	res, err := c.cl.BatchUpdate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return grpcserver.ToBatchResults(res.GetResults()), nil
+}
+
+// DeleteNotes deletes notes in one transaction, see CreateNotes.
+func (c *Client) DeleteNotes(ctx context.Context, refs []models.NoteRef,
+	mode models.BatchMode,
+) ([]models.BatchResult, error) {
+	req := &pb.BatchDeleteRequest{Mode: grpcserver.ToPBBatchMode(mode)}
+	for _, r := range refs {
+		req.Notes = append(req.Notes, &pb.NoteRef{ID: r.ID, ExpectedVersion: r.Version})
+	}
+	res, err := c.cl.BatchDelete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return grpcserver.ToBatchResults(res.GetResults()), nil
+}
+
+// GetNotes returns one page of notes, see Notes to walk all of them.
+func (c *Client) GetNotes(ctx context.Context, filter models.NotesFilter) (models.NotesPage, error) {
+	match := pb.TagMatch_TAG_MATCH_ANY
+	if filter.MatchAllTags {
+		match = pb.TagMatch_TAG_MATCH_ALL
+	}
 	res, err := c.cl.GetNotes(ctx, &pb.GetNotesRequest{
-		TimeInterval: durationpb.New(interval),
+		TimeInterval: durationpb.New(filter.Interval),
+		Tags:         filter.Tags,
+		TagMatch:     match,
+		SortBy:       grpcserver.ToPBNotesSort(filter.SortBy),
+		Descending:   filter.Descending,
+		PageSize:     int32(filter.PageSize),
+		PageToken:    filter.PageToken,
+	})
+	if err != nil {
+		return models.NotesPage{}, err
+	}
+	return models.NotesPage{Notes: grpcserver.ToNotes(res.Notes), NextPageToken: res.NextPageToken}, nil
+}
+
+// Notes iterates over all pages of notes matching filter, starting at filter.PageToken.
+func (c *Client) Notes(ctx context.Context, filter models.NotesFilter) *clients.NotesIterator {
+	return clients.NewNotesIterator(filter.PageToken, func(token string) (models.NotesPage, error) {
+		filter.PageToken = token
+		return c.GetNotes(ctx, filter)
+	})
+}
+
+// ListNotes calls fn for every note matching filter, as the server streams them.
+// Paging fields of filter are ignored.
+func (c *Client) ListNotes(ctx context.Context, filter models.NotesFilter, fn func(models.Note) error) error {
+	match := pb.TagMatch_TAG_MATCH_ANY
+	if filter.MatchAllTags {
+		match = pb.TagMatch_TAG_MATCH_ALL
+	}
+	stream, err := c.cl.ListNotes(ctx, &pb.ListNotesRequest{
+		TimeInterval: durationpb.New(filter.Interval),
+		Tags:         filter.Tags,
+		TagMatch:     match,
+		SortBy:       grpcserver.ToPBNotesSort(filter.SortBy),
+		Descending:   filter.Descending,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		n, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(grpcserver.ToNote(n)); err != nil {
+			return err
+		}
+	}
+}
+
+// Watch calls fn for every change of a note, starting after the event resumeAfter
+// ("" for new events only), until ctx is done or fn returns an error. A broken stream
+// is reopened after the last received event.
+func (c *Client) Watch(ctx context.Context, resumeAfter string, fn func(models.NoteEvent) error,
+	types ...models.NoteEventType,
+) error {
+	backoff := time.Second
+	for {
+		err := c.watch(ctx, &resumeAfter, fn, types)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if code := status.Code(err); code != codes.Unavailable && code != codes.ResourceExhausted {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, time.Second*30)
+	}
+}
+
+func (c *Client) watch(ctx context.Context, resumeAfter *string, fn func(models.NoteEvent) error,
+	types []models.NoteEventType,
+) error {
+	stream, err := c.cl.WatchNotes(ctx, &pb.WatchNotesRequest{
+		ResumeAfter: *resumeAfter,
+		Types:       grpcserver.ToPBNoteEventTypes(types),
 	})
+	if err != nil {
+		return err
+	}
+	for {
+		e, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if err := fn(grpcserver.ToNoteEvent(e)); err != nil {
+			return err
+		}
+		*resumeAfter = e.Id
+	}
+}
+
+func (c *Client) ListTags(ctx context.Context) ([]models.TagCount, error) {
+	res, err := c.cl.ListTags(ctx, &pb.ListTagsRequest{})
 	if err != nil {
 		return nil, err
 	}
-	return grpcserver.ToNotes(res.Notes), nil
+	tags := make([]models.TagCount, 0, len(res.Tags))
+	for _, t := range res.Tags {
+		tags = append(tags, models.TagCount{Name: t.Name, Count: int(t.Count)})
+	}
+	return tags, nil
+}
+
+func (c *Client) Search(ctx context.Context, q models.SearchQuery) (models.SearchPage, error) {
+	res, err := c.cl.SearchNotes(ctx, &pb.SearchNotesRequest{
+		Query:  q.Query,
+		Limit:  int32(q.Limit),
+		Offset: int32(q.Offset),
+	})
+	if err != nil {
+		return models.SearchPage{}, err
+	}
+	return grpcserver.ToSearchPage(res), nil
 }
 
 func (c *Client) GetNote(ctx context.Context, id uint64) (models.Note, error) {
@@ -0,0 +1,138 @@
+package notesgrpcclient_test
+
+import (
+	"context"
+	"net"
+	"notes/internal/notes/server/grpcserver/pb"
+	notesgrpcclient "notes/internal/pkg/clients/notesGRPCclient"
+	"notes/internal/pkg/config"
+	"notes/internal/pkg/models"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// notesServer fails the first failures calls of GetNote and every CreateNote with
+// codes.Unavailable, and blocks paged GetNotes until the call is done.
+type notesServer struct {
+	pb.UnimplementedNotesServer
+	failures int32
+	calls    atomic.Int32
+	interval atomic.Int64
+}
+
+func (s *notesServer) GetNote(_ context.Context, req *pb.GetNoteRequest) (*pb.GetNoteResponse, error) {
+	if s.calls.Add(1) <= s.failures {
+		return nil, status.Error(codes.Unavailable, "try again")
+	}
+	return &pb.GetNoteResponse{Note: &pb.Note{ID: req.ID}}, nil
+}
+
+func (s *notesServer) CreateNote(context.Context, *pb.CreateNoteRequest) (*pb.CreateNoteResponse, error) {
+	s.calls.Add(1)
+	return nil, status.Error(codes.Unavailable, "try again")
+}
+
+func (s *notesServer) GetNotes(ctx context.Context, req *pb.GetNotesRequest) (*pb.GetNotesResponse, error) {
+	s.interval.Store(int64(req.GetTimeInterval().AsDuration()))
+	if req.GetPageSize() == 0 {
+		return &pb.GetNotesResponse{}, nil
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// serve starts srv on a listener named addr, see dialer.
+func serve(t *testing.T, listeners map[string]*bufconn.Listener, addr string, srv pb.NotesServer) {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	listeners[addr] = lis
+	s := grpc.NewServer()
+	pb.RegisterNotesServer(s, srv)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+}
+
+func dialer(listeners map[string]*bufconn.Listener) grpc.DialOption {
+	return grpc.WithContextDialer(func(_ context.Context, addr string) (net.Conn, error) {
+		return listeners[addr].Dial()
+	})
+}
+
+func TestClient(t *testing.T) {
+	srv := &notesServer{failures: 2}
+	listeners := map[string]*bufconn.Listener{}
+	serve(t, listeners, "bufnet", srv)
+
+	c, err := notesgrpcclient.New(config.GRPCServer{Host: "bufnet"},
+		notesgrpcclient.WithDialOptions(dialer(listeners)),
+		// The backoffs of the retries have to fit in the call timeout.
+		notesgrpcclient.WithRetryPolicy(notesgrpcclient.RetryPolicy{
+			MaxAttempts:    4,
+			InitialBackoff: time.Millisecond * 10,
+			MaxBackoff:     time.Millisecond * 50,
+			Multiplier:     2,
+			Codes:          []codes.Code{codes.Unavailable},
+		}),
+		notesgrpcclient.WithCallTimeout(time.Millisecond*200),
+		notesgrpcclient.WithFetchWindow(time.Minute*10),
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("Retries idempotent calls", func(t *testing.T) {
+		note, err := c.GetNote(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), note.ID)
+		assert.Equal(t, int32(3), srv.calls.Load())
+	})
+
+	t.Run("Doesn't retry other calls", func(t *testing.T) {
+		srv.calls.Store(0)
+		_, err := c.CreateNote(ctx, models.Note{Title: "test"})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, int32(1), srv.calls.Load())
+	})
+
+	t.Run("Default deadline", func(t *testing.T) {
+		_, err := c.Notes(ctx, models.NotesFilter{PageSize: 1}).All()
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	})
+
+	t.Run("Fetch window", func(t *testing.T) {
+		_, err := c.Fetch(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(time.Minute*10), srv.interval.Load())
+	})
+
+	assert.NoError(t, c.Close())
+}
+
+func TestRoundRobin(t *testing.T) {
+	listeners := map[string]*bufconn.Listener{}
+	srvs := []*notesServer{{}, {}}
+	serve(t, listeners, "notes-1", srvs[0])
+	serve(t, listeners, "notes-2", srvs[1])
+
+	c, err := notesgrpcclient.New(config.GRPCServer{},
+		notesgrpcclient.WithAddresses("notes-1", "notes-2"),
+		notesgrpcclient.WithDialOptions(dialer(listeners)),
+	)
+	require.NoError(t, err)
+	defer c.Close()
+
+	// Calls go to the first ready server until the other is ready too.
+	assert.Eventually(t, func() bool {
+		_, err := c.GetNote(context.Background(), 1)
+		return err == nil && srvs[0].calls.Load() > 0 && srvs[1].calls.Load() > 0
+	}, time.Second*5, time.Millisecond)
+}
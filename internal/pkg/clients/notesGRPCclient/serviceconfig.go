@@ -0,0 +1,119 @@
+package notesgrpcclient
+
+import (
+	"encoding/json"
+	"notes/internal/notes/server/grpcserver/pb"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// RetryPolicy retries the idempotent calls, the reads of notes and health checks,
+// that fail with one of Codes. Calls are not retried when MaxAttempts is below 2.
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt, gRPC caps it at 5.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Codes          []codes.Code
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: time.Millisecond * 100,
+	MaxBackoff:     time.Second * 2,
+	Multiplier:     2,
+	Codes:          []codes.Code{codes.Unavailable},
+}
+
+// idempotentMethods may run more than once without changing anything.
+var idempotentMethods = map[string]bool{
+	"GetNote":     true,
+	"GetNotes":    true,
+	"ListNotes":   true,
+	"ListTags":    true,
+	"SearchNotes": true,
+}
+
+type serviceConfig struct {
+	LoadBalancingConfig []map[string]struct{} `json:"loadBalancingConfig"`
+	MethodConfig        []methodConfig        `json:"methodConfig,omitempty"`
+}
+
+type methodName struct {
+	Service string `json:"service"`
+	Method  string `json:"method,omitempty"`
+}
+
+type methodConfig struct {
+	Name        []methodName `json:"name"`
+	Timeout     string       `json:"timeout,omitempty"`
+	RetryPolicy *retryPolicy `json:"retryPolicy,omitempty"`
+}
+
+type retryPolicy struct {
+	MaxAttempts          int          `json:"maxAttempts"`
+	InitialBackoff       string       `json:"initialBackoff"`
+	MaxBackoff           string       `json:"maxBackoff"`
+	BackoffMultiplier    float64      `json:"backoffMultiplier"`
+	RetryableStatusCodes []codes.Code `json:"retryableStatusCodes"`
+}
+
+// buildServiceConfig balances calls round-robin over the addresses, retries the
+// idempotent calls and bounds the unary ones by timeout. Streams have no
+// default deadline, they may last as long as their context.
+func buildServiceConfig(retry RetryPolicy, timeout time.Duration) string {
+	var rp *retryPolicy
+	if retry.MaxAttempts > 1 && len(retry.Codes) > 0 {
+		rp = &retryPolicy{
+			MaxAttempts:          retry.MaxAttempts,
+			InitialBackoff:       seconds(retry.InitialBackoff),
+			MaxBackoff:           seconds(retry.MaxBackoff),
+			BackoffMultiplier:    retry.Multiplier,
+			RetryableStatusCodes: retry.Codes,
+		}
+	}
+	var t string
+	if timeout > 0 {
+		t = seconds(timeout)
+	}
+
+	service := pb.Notes_ServiceDesc.ServiceName
+	var retried, unretried, streams []methodName
+	for _, m := range pb.Notes_ServiceDesc.Methods {
+		if idempotentMethods[m.MethodName] {
+			retried = append(retried, methodName{Service: service, Method: m.MethodName})
+			continue
+		}
+		unretried = append(unretried, methodName{Service: service, Method: m.MethodName})
+	}
+	for _, s := range pb.Notes_ServiceDesc.Streams {
+		if idempotentMethods[s.StreamName] {
+			streams = append(streams, methodName{Service: service, Method: s.StreamName})
+		}
+	}
+	retried = append(retried, methodName{Service: healthpb.Health_ServiceDesc.ServiceName, Method: "Check"})
+
+	sc := serviceConfig{
+		LoadBalancingConfig: []map[string]struct{}{{"round_robin": {}}},
+	}
+	for _, mc := range []methodConfig{
+		{Name: retried, Timeout: t, RetryPolicy: rp},
+		{Name: unretried, Timeout: t},
+		{Name: streams, RetryPolicy: rp},
+	} {
+		if len(mc.Name) > 0 && (mc.Timeout != "" || mc.RetryPolicy != nil) {
+			sc.MethodConfig = append(sc.MethodConfig, mc)
+		}
+	}
+	b, _ := json.Marshal(sc)
+	return string(b)
+}
+
+// seconds formats d as a protobuf JSON duration.
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
@@ -1,6 +1,7 @@
 package notesclient
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -8,125 +9,522 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"notes/internal/notes/idempotency"
+	"notes/internal/pkg/auth"
+	"notes/internal/pkg/clients"
 	"notes/internal/pkg/config"
 	"notes/internal/pkg/models"
-	"path"
+	"notes/internal/pkg/tlsconfig"
 	"strconv"
+	"strings"
 	"time"
 )
 
-var ErrNotFound = errors.New("not found")
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is returned when the caller may not do what it asked.
+	ErrForbidden = errors.New("forbidden")
+	// ErrConflict is returned when a request with the same idempotency key is
+	// still in progress or was sent with another body.
+	ErrConflict = errors.New("conflict")
+	// ErrVersionConflict is returned when the note has changed since it was read.
+	ErrVersionConflict = errors.New("version conflict")
+	ErrBadRequest      = errors.New("bad request")
+	// ErrTooManyRequests is returned when the caller is rate limited or over its quotas.
+	ErrTooManyRequests = errors.New("too many requests")
+)
 
-var notesPath = "notes"
+// DefaultTimeout bounds every request unless WithTimeout sets another one.
+const DefaultTimeout = time.Second * 5
 
-type NotesClient struct {
-	client *http.Client
-	cfg    config.Server
+const notesPath = "v1/notes"
+
+// headerNextPageToken mirrors ginserver.HeaderNextPageToken.
+const headerNextPageToken = "X-Next-Page-Token"
+
+// APIError is the error of a response with an error status. It unwraps to
+// ErrNotFound, ErrUnauthorized, ErrForbidden, ErrConflict, ErrVersionConflict,
+// ErrBadRequest or ErrTooManyRequests when the status has one of their meanings.
+type APIError struct {
+	StatusCode int
+	// Message is the error sent by the server, or the status text without one.
+	Message string
+	// RetryAfter is how long the server asked to wait before retrying, if it did.
+	RetryAfter time.Duration
+	// index is the failing item of an atomic batch, see BatchError.
+	index *int
 }
 
-func New(cfg config.Server) NotesClient {
-	return NotesClient{
-		client: http.DefaultClient,
-		cfg:    cfg,
+func (e *APIError) Error() string {
+	return fmt.Sprintf("notes API: %d %s", e.StatusCode, e.Message)
+}
+
+func (e *APIError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusConflict, http.StatusUnprocessableEntity:
+		return ErrConflict
+	case http.StatusPreconditionFailed:
+		return ErrVersionConflict
+	case http.StatusBadRequest:
+		return ErrBadRequest
+	case http.StatusTooManyRequests:
+		return ErrTooManyRequests
 	}
+	return nil
+}
+
+// BatchError is returned by atomic batches that failed as a whole because of
+// the item at Index.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("batch item %d: %s", e.Index, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+type NotesClient struct {
+	client  *http.Client
+	baseURL *url.URL
+	timeout time.Duration
+	creds   auth.Credentials
+	// err fails every request when the client could not be set up.
+	err error
 }
 
-func (n *NotesClient) GetNoteRequest(id uint64) (models.Note, error) {
-	note := models.Note{}
+type Option func(*NotesClient)
 
-	s := strconv.Itoa(int(id))
-	u := url.URL{
-		Scheme: "http",
-		Host:   n.cfg.Host + n.cfg.Port,
-		Path:   path.Join(notesPath, s),
+// WithAPIKey authenticates requests with an API key.
+func WithAPIKey(key string) Option {
+	return func(n *NotesClient) {
+		n.creds.APIKey = key
 	}
+}
 
-	b, err := n.DoRequest(http.MethodGet, u.String())
-	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			return note, ErrNotFound
+// WithBearerToken authenticates requests with a signed JWT.
+func WithBearerToken(token string) Option {
+	return func(n *NotesClient) {
+		n.creds.BearerToken = token
+	}
+}
+
+// WithBaseURL sends requests to the API at rawURL, such as
+// https://notes.example.com/api, instead of the host and port of the config.
+func WithBaseURL(rawURL string) Option {
+	return func(n *NotesClient) {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			n.err = fmt.Errorf("bad base URL: %w", err)
+			return
 		}
-		return note, fmt.Errorf("cannot do request to server error: %w", err)
+		n.baseURL = u
 	}
+}
 
-	err = json.Unmarshal(b, &note)
-	if err != nil {
-		return note, fmt.Errorf("cannot unmarshal server's response error: %w", err)
+// WithTimeout bounds every request by d, zero leaves them to their context.
+func WithTimeout(d time.Duration) Option {
+	return func(n *NotesClient) {
+		n.timeout = d
 	}
+}
 
+// WithHTTPClient sends the requests with c, its transport replaces the one
+// configured from the TLS settings.
+func WithHTTPClient(c *http.Client) Option {
+	return func(n *NotesClient) {
+		n.client = c
+	}
+}
+
+func New(cfg config.Server, opts ...Option) *NotesClient {
+	n := &NotesClient{
+		client:  http.DefaultClient,
+		baseURL: &url.URL{Scheme: "http", Host: cfg.Host + cfg.Port, Path: "/"},
+		timeout: DefaultTimeout,
+	}
+	if cfg.TLS.Enabled {
+		tlsCfg, err := tlsconfig.Client(cfg.TLS)
+		n.client = &http.Client{Transport: &http.Transport{TLSClientConfig: tlsCfg}}
+		n.baseURL.Scheme, n.err = "https", err
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns ctx that makes the mutating requests made with it
+// run once on the server. Retries of a request must use the same key.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// GetNote returns the note with id.
+func (n *NotesClient) GetNote(ctx context.Context, id uint64) (models.Note, error) {
+	var note models.Note
+	_, err := n.do(ctx, http.MethodGet, notePath(id), nil, nil, nil, &note)
 	return note, err
 }
 
-func (n *NotesClient) GetNotesRequest() ([]models.Note, error) {
-	u := url.URL{
-		Scheme: "http",
-		Host:   n.cfg.Host + n.cfg.Port,
-		Path:   notesPath,
+// GetNotes returns one page of notes matching filter.
+func (n *NotesClient) GetNotes(ctx context.Context, filter models.NotesFilter) (models.NotesPage, error) {
+	page := models.NotesPage{Notes: make([]models.Note, 0, 4)}
+	h, err := n.do(ctx, http.MethodGet, notesPath, filterQuery(filter), nil, nil, &page.Notes)
+	if err != nil {
+		return models.NotesPage{}, err
 	}
+	page.NextPageToken = h.Get(headerNextPageToken)
+	return page, nil
+}
+
+// Notes iterates over all pages of notes matching filter, starting at filter.PageToken.
+func (n *NotesClient) Notes(ctx context.Context, filter models.NotesFilter) *clients.NotesIterator {
+	return clients.NewNotesIterator(filter.PageToken, func(token string) (models.NotesPage, error) {
+		filter.PageToken = token
+		return n.GetNotes(ctx, filter)
+	})
+}
+
+// ListTags returns every tag with the number of notes that have it.
+func (n *NotesClient) ListTags(ctx context.Context) ([]models.TagCount, error) {
+	var tags []models.TagCount
+	_, err := n.do(ctx, http.MethodGet, "v1/tags", nil, nil, nil, &tags)
+	return tags, err
+}
+
+// CreateNote returns the created note. It can be retried safely with a context
+// from WithIdempotencyKey.
+func (n *NotesClient) CreateNote(ctx context.Context, note models.Note) (models.Note, error) {
+	var created models.Note
+	_, err := n.do(ctx, http.MethodPost, notesPath, nil, nil, note, &created)
+	return created, err
+}
+
+// UpdateNote updates the fields of note that are set. It fails with
+// ErrVersionConflict when note.Version is set and the note has another version.
+func (n *NotesClient) UpdateNote(ctx context.Context, note models.Note) error {
+	_, err := n.do(ctx, http.MethodPatch, notePath(note.ID), nil, ifMatch(note.Version), note, nil)
+	return err
+}
+
+// ReplaceNote sets every field of note a client can change, clearing those that
+// are zero. It fails with ErrVersionConflict when note.Version is set and the
+// note has another version.
+func (n *NotesClient) ReplaceNote(ctx context.Context, note models.Note) error {
+	_, err := n.do(ctx, http.MethodPut, notePath(note.ID), nil, ifMatch(note.Version), note, nil)
+	return err
+}
+
+// mergePatchContentType mirrors ginserver.MergePatchContentType.
+const mergePatchContentType = "application/merge-patch+json"
 
-	b, err := n.DoRequest(http.MethodGet, u.String())
+// UpdateNoteFields sets only the given fields of note, clearing those that are zero.
+// Without fields it behaves like UpdateNote.
+func (n *NotesClient) UpdateNoteFields(ctx context.Context, note models.Note, fields ...models.NoteField) error {
+	if len(fields) == 0 {
+		return n.UpdateNote(ctx, note)
+	}
+	patch, err := mergePatch(note, fields)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	h := ifMatch(note.Version)
+	h.Set("Content-Type", mergePatchContentType)
+	_, err = n.do(ctx, http.MethodPatch, notePath(note.ID), nil, h, patch, nil)
+	return err
+}
 
-	notes := make([]models.Note, 0, 4)
-	if err := json.Unmarshal(b, &notes); err != nil {
-		return nil, fmt.Errorf("cannot unmarshal server's response error: %w", err)
+// mergePatch sets the members of fields, null for the zero ones.
+func mergePatch(note models.Note, fields []models.NoteField) (map[string]interface{}, error) {
+	patch := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		switch f {
+		case models.FieldTitle:
+			patch["title"] = nullIf(note.Title == "", note.Title)
+		case models.FieldDescription:
+			patch["description"] = nullIf(note.Description == "", note.Description)
+		case models.FieldDateNotify:
+			patch["dateNotify"] = nullIf(note.DateNotify.IsZero(), note.DateNotify)
+		case models.FieldDelay:
+			patch["delay"] = nullIf(note.Delay == 0, note.Delay)
+		case models.FieldTags:
+			patch["tags"] = nullIf(len(note.Tags) == 0, note.Tags)
+		default:
+			return nil, fmt.Errorf("%w: unknown field %q", ErrBadRequest, f)
+		}
 	}
-	return notes, nil
+	return patch, nil
 }
 
-func (n *NotesClient) DeleteNoteRequest(id uint64) error {
-	q := url.Values{}
-	q.Add("id", strconv.Itoa(int(id)))
-	u := url.URL{
-		Scheme:   "http",
-		Host:     n.cfg.Host + n.cfg.Port,
-		Path:     notesPath,
-		RawQuery: q.Encode(),
+func nullIf(zero bool, v interface{}) interface{} {
+	if zero {
+		return nil
 	}
+	return v
+}
 
-	fmt.Println(u.String())
-	_, err := n.DoRequest(http.MethodDelete, u.String())
+// RefreshNote reschedules a due note after its delay and multiplies the delay by ten.
+// deleted is true when the note was deleted because its delay grew too long.
+func (n *NotesClient) RefreshNote(ctx context.Context, id uint64) (_ models.Note, deleted bool, err error) {
+	var note models.Note
+	_, err = n.do(ctx, http.MethodPost, notePath(id)+":refresh", nil, nil, nil, &note)
 	if err != nil {
-		return err
+		return models.Note{}, false, err
 	}
+	// The server replies with no content, so no note, when it deleted the note.
+	return note, note.ID == 0, nil
+}
 
-	return nil
+// SnoozeNote postpones the reminder of a note until the given time.
+func (n *NotesClient) SnoozeNote(ctx context.Context, id uint64, until time.Time) (models.Note, error) {
+	return n.noteAction(ctx, id, "snooze", map[string]interface{}{"until": until})
 }
 
-func (n *NotesClient) UpdateNoteRequest(_ models.Note) error {
-	panic("not implemented")
-	// return nil
+// SnoozeNoteFor postpones the reminder of a note by d from now.
+func (n *NotesClient) SnoozeNoteFor(ctx context.Context, id uint64, d time.Duration) (models.Note, error) {
+	return n.noteAction(ctx, id, "snooze", map[string]interface{}{"for": d.String()})
 }
 
-func (n *NotesClient) CreateNoteRequest(_ models.Note) error {
-	panic("not implemented")
-	// return nil
+// AcknowledgeNote stops the reminders of a note until it is snoozed or rescheduled.
+func (n *NotesClient) AcknowledgeNote(ctx context.Context, id uint64) (models.Note, error) {
+	return n.noteAction(ctx, id, "acknowledge", nil)
 }
 
-func (n *NotesClient) DoRequest(method string, url string) ([]byte, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
-	defer cancel()
+func (n *NotesClient) noteAction(ctx context.Context, id uint64, action string, body interface{}) (models.Note, error) {
+	var note models.Note
+	_, err := n.do(ctx, http.MethodPost, notePath(id)+":"+action, nil, nil, body, &note)
+	return note, err
+}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+// batchRequest mirrors the body of POST /v1/notes:batch in ginserver.
+type batchRequest struct {
+	Mode   models.BatchMode    `json:"mode,omitempty"`
+	Create []models.Note       `json:"create,omitempty"`
+	Update []models.NoteUpdate `json:"update,omitempty"`
+	Delete []models.NoteRef    `json:"delete,omitempty"`
+	// KeepSchedule is set by ImportNotes.
+	KeepSchedule bool `json:"keepSchedule,omitempty"`
+}
+
+type batchResponse struct {
+	Results []struct {
+		ID      uint64 `json:"id"`
+		Version uint64 `json:"version"`
+		Status  int    `json:"status"`
+		Error   string `json:"error"`
+	} `json:"results"`
+}
+
+// CreateNotes creates notes in one transaction. Atomic batches, the default,
+// fail with a *BatchError, failed items of partial batches have an *APIError
+// in their result.
+func (n *NotesClient) CreateNotes(ctx context.Context, notes []models.Note,
+	mode models.BatchMode,
+) ([]models.BatchResult, error) {
+	return n.batch(ctx, batchRequest{Mode: mode, Create: notes})
+}
+
+// ImportNotes creates notes like CreateNotes, but they keep their delay and a
+// notify date in the future, such as those of exported notes.
+func (n *NotesClient) ImportNotes(ctx context.Context, notes []models.Note,
+	mode models.BatchMode,
+) ([]models.BatchResult, error) {
+	return n.batch(ctx, batchRequest{Mode: mode, Create: notes, KeepSchedule: true})
+}
+
+// UpdateNotes applies updates in one transaction, see CreateNotes.
+func (n *NotesClient) UpdateNotes(ctx context.Context, updates []models.NoteUpdate,
+	mode models.BatchMode,
+) ([]models.BatchResult, error) {
+	return n.batch(ctx, batchRequest{Mode: mode, Update: updates})
+}
+
+// DeleteNotes deletes notes in one transaction, see CreateNotes.
+func (n *NotesClient) DeleteNotes(ctx context.Context, refs []models.NoteRef,
+	mode models.BatchMode,
+) ([]models.BatchResult, error) {
+	return n.batch(ctx, batchRequest{Mode: mode, Delete: refs})
+}
+
+func (n *NotesClient) batch(ctx context.Context, req batchRequest) ([]models.BatchResult, error) {
+	var res batchResponse
+	_, err := n.do(ctx, http.MethodPost, notesPath+":batch", nil, nil, req, &res)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.index != nil {
+		return nil, &BatchError{Index: *apiErr.index, Err: apiErr}
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := n.client.Do(req)
+	results := make([]models.BatchResult, len(res.Results))
+	for i, r := range res.Results {
+		results[i] = models.BatchResult{ID: r.ID, Version: r.Version}
+		if r.Status >= http.StatusBadRequest {
+			results[i].Err = &APIError{StatusCode: r.Status, Message: r.Error}
+		}
+	}
+	return results, nil
+}
+
+// Search returns the notes matching q.Query, best matches first.
+func (n *NotesClient) Search(ctx context.Context, q models.SearchQuery) (models.SearchPage, error) {
+	query := url.Values{}
+	query.Set("q", q.Query)
+	if q.Limit > 0 {
+		query.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Offset > 0 {
+		query.Set("offset", strconv.Itoa(q.Offset))
+	}
+	var page models.SearchPage
+	_, err := n.do(ctx, http.MethodGet, notesPath+"/search", query, nil, nil, &page)
+	return page, err
+}
+
+// DeleteNote deletes the note if it has the version, or any version when it is zero.
+func (n *NotesClient) DeleteNote(ctx context.Context, id uint64, version uint64) error {
+	_, err := n.do(ctx, http.MethodDelete, notePath(id), nil, ifMatch(version), nil, nil)
+	return err
+}
+
+func notePath(id uint64) string {
+	return notesPath + "/" + strconv.FormatUint(id, 10)
+}
+
+// ifMatch requires the version, any version when it is zero.
+func ifMatch(version uint64) http.Header {
+	h := http.Header{}
+	h.Set("If-Match", "*")
+	if version != 0 {
+		h.Set("If-Match", `"`+strconv.FormatUint(version, 10)+`"`)
+	}
+	return h
+}
+
+func filterQuery(filter models.NotesFilter) url.Values {
+	q := url.Values{}
+	if filter.Interval > 0 {
+		q.Set("interval", filter.Interval.String())
+	}
+	if len(filter.Tags) > 0 {
+		q.Set("tags", strings.Join(filter.Tags, ","))
+	}
+	if filter.MatchAllTags {
+		q.Set("match", "all")
+	}
+	if filter.SortBy != "" {
+		q.Set("sort", filter.SortBy)
+	}
+	if filter.Descending {
+		q.Set("order", "desc")
+	}
+	if filter.PageSize > 0 {
+		q.Set("page_size", strconv.Itoa(filter.PageSize))
+	}
+	if filter.PageToken != "" {
+		q.Set("page_token", filter.PageToken)
+	}
+	return q
+}
+
+// do sends body as JSON to the path relative to the base URL and decodes the
+// response into out, when both are set. Error statuses are returned as *APIError.
+func (n *NotesClient) do(ctx context.Context, method, path string, query url.Values,
+	h http.Header, body, out interface{},
+) (http.Header, error) {
+	if n.err != nil {
+		return nil, n.err
+	}
+	if n.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, n.timeout)
+		defer cancel()
+	}
+
+	u := n.baseURL.JoinPath(path)
+	u.RawQuery = query.Encode()
+
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("cannot marshal request: %w", err)
+		}
+		r = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), r)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, ErrNotFound
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range h {
+		req.Header[k] = v
 	}
+	req.Header.Set("Accept", "application/json")
+	if key, ok := ctx.Value(idempotencyKey{}).(string); ok {
+		req.Header.Set(idempotency.HeaderKey, key)
+	}
+	n.creds.SetHeader(req.Header)
 
-	b, err := io.ReadAll(resp.Body)
+	resp, err := n.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
-	return b, err
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp.Header, decodeError(resp)
+	}
+	if out != nil && resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.Header, fmt.Errorf("cannot unmarshal server's response error: %w", err)
+		}
+	}
+	return resp.Header, nil
+}
+
+// maxErrorBody bounds the error bodies read into APIError.Message.
+const maxErrorBody = 4096
+
+// decodeError reads the error of a JSON body such as {"error": "..."} or a text
+// body into an APIError. Failed atomic batches also have the "index" of their
+// failing item.
+func decodeError(resp *http.Response) error {
+	e := &APIError{StatusCode: resp.StatusCode}
+	if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		e.RetryAfter = time.Duration(s) * time.Second
+	}
+
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	var body struct {
+		Error string `json:"error"`
+		Index *int   `json:"index"`
+	}
+	switch {
+	case json.Unmarshal(b, &body) == nil && body.Error != "":
+		e.Message, e.index = body.Error, body.Index
+	case len(bytes.TrimSpace(b)) > 0 && !json.Valid(b):
+		e.Message = string(bytes.TrimSpace(b))
+	default:
+		e.Message = http.StatusText(resp.StatusCode)
+	}
+	return e
 }
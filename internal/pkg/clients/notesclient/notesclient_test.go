@@ -0,0 +1,273 @@
+package notesclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"notes/internal/notes/app"
+	"notes/internal/notes/ratelimit"
+	"notes/internal/notes/server"
+	"notes/internal/notes/server/ginserver"
+	"notes/internal/notes/storage"
+	"notes/internal/pkg/auth"
+	"notes/internal/pkg/clients/notesclient"
+	"notes/internal/pkg/config"
+	"notes/internal/pkg/logger"
+	"notes/internal/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var nilError error
+
+func TestClient(t *testing.T) {
+	mockStr := new(storage.MockStorage)
+	logg, err := logger.New(logger.EnvLocal)
+	require.NoError(t, err)
+
+	limiter := ratelimit.New(config.RateLimit{
+		Routes: map[string]config.Limit{"GET /v1/tags": {Rate: 0.001, Burst: 1}},
+	})
+	serv := ginserver.New(app.NewApp(mockStr), config.Server{ShutDownTimeout: 5}, logg,
+		server.WithRateLimiter(limiter))
+	ts := httptest.NewServer(serv)
+	defer ts.Close()
+
+	client := notesclient.New(config.Server{}, notesclient.WithBaseURL(ts.URL))
+	ctx := context.Background()
+
+	t.Run("Create and get note", func(t *testing.T) {
+		created := models.Note{ID: 1, Title: "test", Tags: []string{"home"}, Version: 1}
+		mockStr.On("CreateNote", ctx, mock.MatchedBy(func(n models.Note) bool {
+			return n.Title == "test"
+		})).Return(created, nilError).Once()
+		mockStr.On("GetNote", ctx, uint64(1)).Return(created, nilError).Once()
+
+		note, err := client.CreateNote(ctx, models.Note{Title: "test", Tags: []string{"home"}})
+		require.NoError(t, err)
+		assert.Equal(t, created, note)
+
+		note, err = client.GetNote(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, created, note)
+	})
+
+	t.Run("Typed errors", func(t *testing.T) {
+		mockStr.On("GetNote", ctx, uint64(2)).Return(models.Note{}, storage.ErrNotFound).Once()
+		mockStr.On("UpdateNote", ctx, models.Note{ID: 3, Title: "new", Version: 4},
+			[]models.NoteField{models.FieldTitle}).Return(storage.ErrVersionConflict).Once()
+
+		_, err := client.GetNote(ctx, 2)
+		assert.ErrorIs(t, err, notesclient.ErrNotFound)
+		var apiErr *notesclient.APIError
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+
+		err = client.UpdateNote(ctx, models.Note{ID: 3, Title: "new", Version: 4})
+		assert.ErrorIs(t, err, notesclient.ErrVersionConflict)
+	})
+
+	t.Run("Update fields and refresh", func(t *testing.T) {
+		mockStr.On("UpdateNote", ctx, models.Note{ID: 4, Title: "new", Tags: []string{}, Version: 2},
+			[]models.NoteField{models.FieldTitle, models.FieldTags}).Return(nilError).Once()
+		mockStr.On("RefreshNote", ctx, uint64(4), app.MaxRefreshDelay).
+			Return(models.Note{ID: 4, Version: 3}, false, nilError).Once()
+		mockStr.On("RefreshNote", ctx, uint64(5), app.MaxRefreshDelay).Return(models.Note{}, true, nilError).Once()
+
+		// Tags are cleared, the description is left as it is.
+		err := client.UpdateNoteFields(ctx, models.Note{ID: 4, Title: "new", Description: "kept", Version: 2},
+			models.FieldTitle, models.FieldTags)
+		require.NoError(t, err)
+
+		note, deleted, err := client.RefreshNote(ctx, 4)
+		require.NoError(t, err)
+		assert.False(t, deleted)
+		assert.Equal(t, uint64(3), note.Version)
+
+		_, deleted, err = client.RefreshNote(ctx, 5)
+		require.NoError(t, err)
+		assert.True(t, deleted)
+	})
+
+	t.Run("Delete note", func(t *testing.T) {
+		mockStr.On("DeleteNote", ctx, uint64(5), uint64(0)).Return(nilError).Once()
+		mockStr.On("DeleteNote", ctx, uint64(6), uint64(2)).Return(nilError).Once()
+
+		assert.NoError(t, client.DeleteNote(ctx, 5, 0))
+		assert.NoError(t, client.DeleteNote(ctx, 6, 2))
+	})
+
+	t.Run("Replace note", func(t *testing.T) {
+		mockStr.On("UpdateNote", ctx, models.Note{ID: 9, Title: "new", Tags: []string{}, Version: 2},
+			[]models.NoteField{
+				models.FieldTitle, models.FieldDescription, models.FieldDateNotify, models.FieldDelay, models.FieldTags,
+			}).Return(nilError).Once()
+		mockStr.On("UpdateNote", ctx, models.Note{ID: 9, Title: "old", Tags: []string{}, Version: 2},
+			mock.Anything).Return(storage.ErrVersionConflict).Once()
+
+		require.NoError(t, client.ReplaceNote(ctx, models.Note{ID: 9, Title: "new", Version: 2}))
+		err := client.ReplaceNote(ctx, models.Note{ID: 9, Title: "old", Version: 2})
+		assert.ErrorIs(t, err, notesclient.ErrVersionConflict)
+	})
+
+	t.Run("Snooze and acknowledge", func(t *testing.T) {
+		until := time.Now().Add(time.Hour).Truncate(time.Second)
+		snoozed := models.Note{ID: 10, DateNotify: until.UTC(), Version: 2}
+		mockStr.On("SnoozeNote", ctx, uint64(10), mock.MatchedBy(func(tm time.Time) bool {
+			return tm.Equal(until)
+		})).Return(snoozed, nilError).Once()
+		mockStr.On("SnoozeNote", ctx, uint64(11), mock.MatchedBy(func(tm time.Time) bool {
+			return tm.After(until.Add(time.Hour))
+		})).Return(models.Note{ID: 11}, nilError).Once()
+		mockStr.On("AcknowledgeNote", ctx, uint64(12)).Return(models.Note{ID: 12, Version: 3}, nilError).Once()
+		mockStr.On("AcknowledgeNote", ctx, uint64(13)).Return(models.Note{}, storage.ErrNotFound).Once()
+
+		note, err := client.SnoozeNote(ctx, 10, until)
+		require.NoError(t, err)
+		assert.Equal(t, snoozed, note)
+
+		note, err = client.SnoozeNoteFor(ctx, 11, time.Hour*2)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(11), note.ID)
+
+		_, err = client.SnoozeNote(ctx, 10, time.Now().Add(-time.Hour))
+		assert.ErrorIs(t, err, notesclient.ErrBadRequest)
+
+		note, err = client.AcknowledgeNote(ctx, 12)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), note.Version)
+
+		_, err = client.AcknowledgeNote(ctx, 13)
+		assert.ErrorIs(t, err, notesclient.ErrNotFound)
+	})
+
+	t.Run("Batches", func(t *testing.T) {
+		mockStr.On("CreateNotes", ctx, mock.MatchedBy(func(notes []models.Note) bool {
+			return len(notes) == 2 && notes[1].Title == "b"
+		}), models.BatchAtomic).Return([]models.BatchResult{{ID: 14, Version: 1}, {ID: 15, Version: 1}}, nilError).Once()
+		updates := []models.NoteUpdate{
+			{Note: models.Note{ID: 14, Title: "c", Version: 1}, Fields: []models.NoteField{models.FieldTitle}},
+			{Note: models.Note{ID: 15, Title: "d", Version: 3}, Fields: []models.NoteField{models.FieldTitle}},
+		}
+		mockStr.On("UpdateNotes", ctx, updates, models.BatchPartial).
+			Return([]models.BatchResult{{ID: 14, Version: 2}, {ID: 15, Err: storage.ErrVersionConflict}}, nilError).Once()
+		refs := []models.NoteRef{{ID: 14}, {ID: 16, Version: 2}}
+		mockStr.On("DeleteNotes", ctx, refs, models.BatchAtomic).
+			Return([]models.BatchResult(nil), &storage.BatchError{Index: 1, Err: storage.ErrNotFound}).Once()
+
+		results, err := client.CreateNotes(ctx, []models.Note{{Title: "a"}, {Title: "b"}}, "")
+		require.NoError(t, err)
+		assert.Equal(t, []models.BatchResult{{ID: 14, Version: 1}, {ID: 15, Version: 1}}, results)
+
+		// Imported notes keep their schedule.
+		due := time.Now().Add(time.Hour).Truncate(time.Second)
+		mockStr.On("CreateNotes", ctx, mock.MatchedBy(func(notes []models.Note) bool {
+			return len(notes) == 1 && notes[0].DateNotify.Equal(due) && notes[0].Delay == time.Hour
+		}), models.BatchAtomic).Return([]models.BatchResult{{ID: 17, Version: 1}}, nilError).Once()
+		results, err = client.ImportNotes(ctx, []models.Note{{Title: "e", DateNotify: due, Delay: time.Hour}},
+			models.BatchAtomic)
+		require.NoError(t, err)
+		assert.Equal(t, []models.BatchResult{{ID: 17, Version: 1}}, results)
+
+		results, err = client.UpdateNotes(ctx, updates, models.BatchPartial)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, models.BatchResult{ID: 14, Version: 2}, results[0])
+		assert.ErrorIs(t, results[1].Err, notesclient.ErrVersionConflict)
+
+		_, err = client.DeleteNotes(ctx, refs, models.BatchAtomic)
+		var batchErr *notesclient.BatchError
+		require.True(t, errors.As(err, &batchErr))
+		assert.Equal(t, 1, batchErr.Index)
+		assert.ErrorIs(t, err, notesclient.ErrNotFound)
+	})
+
+	t.Run("Walk pages", func(t *testing.T) {
+		filter := models.NotesFilter{Tags: []string{"work"}, PageSize: 1}
+		mockStr.On("GetNotes", ctx, filter).Return(models.NotesPage{
+			Notes: []models.Note{{ID: 7}}, NextPageToken: "next",
+		}, nilError).Once()
+		next := filter
+		next.PageToken = "next"
+		mockStr.On("GetNotes", ctx, next).Return(models.NotesPage{
+			Notes: []models.Note{{ID: 8}},
+		}, nilError).Once()
+
+		notes, err := client.Notes(ctx, filter).All()
+		require.NoError(t, err)
+		require.Len(t, notes, 2)
+		assert.Equal(t, uint64(8), notes[1].ID)
+	})
+
+	t.Run("Rate limited", func(t *testing.T) {
+		mockStr.On("ListTags", ctx).Return([]models.TagCount{{Name: "home", Count: 1}}, nilError).Once()
+
+		tags, err := client.ListTags(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tags, 1)
+
+		_, err = client.ListTags(ctx)
+		assert.ErrorIs(t, err, notesclient.ErrTooManyRequests)
+		var apiErr *notesclient.APIError
+		require.True(t, errors.As(err, &apiErr))
+		assert.Greater(t, apiErr.RetryAfter, time.Duration(0))
+	})
+
+	t.Run("Canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := client.GetNote(ctx, 1)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	mockStr.AssertExpectations(t)
+}
+
+func TestAuth(t *testing.T) {
+	var got http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	client := notesclient.New(config.Server{},
+		notesclient.WithBaseURL(ts.URL), notesclient.WithBearerToken("token"), notesclient.WithTimeout(time.Second))
+	_, err := client.GetNote(notesclient.WithIdempotencyKey(context.Background(), "key-1"), 1)
+	assert.ErrorIs(t, err, notesclient.ErrUnauthorized)
+	assert.Equal(t, "Bearer token", got.Get(auth.HeaderAuthorization))
+	assert.Equal(t, "key-1", got.Get("Idempotency-Key"))
+
+	client = notesclient.New(config.Server{}, notesclient.WithBaseURL("://bad"))
+	_, err = client.GetNote(context.Background(), 1)
+	assert.Error(t, err)
+}
+
+func TestStatusErrors(t *testing.T) {
+	for _, tc := range []struct {
+		status int
+		err    error
+	}{
+		{http.StatusForbidden, notesclient.ErrForbidden},
+		{http.StatusConflict, notesclient.ErrConflict},
+		{http.StatusUnprocessableEntity, notesclient.ErrConflict},
+	} {
+		t.Run(http.StatusText(tc.status), func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+			}))
+			defer ts.Close()
+
+			client := notesclient.New(config.Server{}, notesclient.WithBaseURL(ts.URL))
+			_, err := client.CreateNote(notesclient.WithIdempotencyKey(context.Background(), "key-1"),
+				models.Note{Title: "test"})
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
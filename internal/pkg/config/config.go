@@ -1,38 +1,118 @@
 package config
 
 import (
+	"reflect"
+	"time"
+
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Env        string     `yaml:"env" env:"ENV" env-required:"true"`
-	DB         DB         `yaml:"db"`
-	Server     Server     `yaml:"server"`
-	GRPCServer GRPCServer `yaml:"grpcServer"`
-	Kafka      Kafka      `yaml:"kafka"`
-	Bot        Bot        `yaml:"bot"`
+	Env         string      `yaml:"env" env:"ENV" env-required:"true"`
+	DB          DB          `yaml:"db"`
+	Server      Server      `yaml:"server"`
+	GRPCServer  GRPCServer  `yaml:"grpcServer"`
+	Gateway     Gateway     `yaml:"gateway"`
+	Connect     Connect     `yaml:"connect"`
+	Kafka       Kafka       `yaml:"kafka"`
+	Bot         Bot         `yaml:"bot"`
+	Tracing     Tracing     `yaml:"tracing"`
+	Metrics     Metrics     `yaml:"metrics"`
+	Auth        Auth        `yaml:"auth"`
+	Events      Events      `yaml:"events"`
+	Idempotency Idempotency `yaml:"idempotency"`
+	RateLimit   RateLimit   `yaml:"rateLimit"`
+	Quotas      Quotas      `yaml:"quotas"`
 }
 
 type DB struct {
 	Username string `yaml:"username" env-required:"true"`
-	Password string `yaml:"password" env:"POSTGRES_PASSWORD"`
+	Password string `yaml:"password" env:"POSTGRES_PASSWORD" secret:"true"`
 	Host     string `yaml:"host" env-required:"true"`
 	Port     string `yaml:"port" env-required:"true"`
 	DB       string `yaml:"dbType"`
 	Reload   bool   `yaml:"reload"`
 	Version  int64  `yaml:"version"`
+	// SearchLanguage is the Postgres text search configuration used for notes.
+	SearchLanguage string `yaml:"searchLanguage" env-default:"english"`
+	TLS            TLS    `yaml:"tls"`
 }
 
 type Server struct {
 	Host            string `yaml:"host"`
 	Port            string `yaml:"port"`
 	ShutDownTimeout int64  `yaml:"shutdown"`
+	TLS             TLS    `yaml:"tls"`
+	// TrustedProxies are the addresses or CIDRs whose X-Forwarded-For header gives
+	// the client IP. Empty trusts none, the IP is that of the connection.
+	TrustedProxies []string `yaml:"trustedProxies"`
 }
 
 type GRPCServer struct {
 	Host string `yaml:"host"`
 	Port string `yaml:"port"`
+	// Reflection and Channelz register the grpc.reflection and grpc.channelz services
+	// used by tools such as grpcurl and grpcdebug.
+	Reflection bool `yaml:"reflection"`
+	Channelz   bool `yaml:"channelz"`
+	// Admin registers the Admin service, which provisions users and credentials.
+	// It needs auth enabled and AdminServices, the server fails to start without them.
+	Admin bool `yaml:"admin"`
+	// AdminServices are the names of the service principals allowed to call the
+	// Admin service, other services and users are denied.
+	AdminServices []string `yaml:"adminServices"`
+	// Interceptors is the order of the interceptor chain, outermost first, and must
+	// name every enabled one. Empty keeps the default order: recovery, requestID,
+	// tracing, auth, rateLimit, logging, metrics, idempotency.
+	Interceptors []string `yaml:"interceptors"`
+	TLS          TLS      `yaml:"tls"`
+	// TrustedProxies are the addresses or CIDRs of the gateway and Connect proxies,
+	// whose x-client-ip metadata gives the client IP. Empty trusts none, the IP is
+	// that of the connection.
+	TrustedProxies []string `yaml:"trustedProxies"`
+}
+
+// Gateway is the listener of the REST API that grpc-gateway generates from notes.proto,
+// it proxies requests to the gRPC server. Empty Port disables it.
+type Gateway struct {
+	Host string `yaml:"host"`
+	Port string `yaml:"port"`
+	TLS  TLS    `yaml:"tls"`
+	// ClientTLS dials the gRPC server: its CA, and the client certificate when
+	// it requires one. It must be enabled when the gRPC server uses TLS.
+	ClientTLS TLS `yaml:"clientTLS"`
+}
+
+// Connect serves the Notes service over the Connect and gRPC-Web protocols for
+// browsers, proxied to the gRPC server. It shares the REST port when Port is empty.
+type Connect struct {
+	Enabled bool   `yaml:"enabled"`
+	Host    string `yaml:"host"`
+	Port    string `yaml:"port"`
+	// AllowedOrigins are the CORS origins allowed to call the service, "*" allows any.
+	AllowedOrigins []string `yaml:"allowedOrigins"`
+	// TLS secures the dedicated port, the REST one has its own settings.
+	TLS TLS `yaml:"tls"`
+	// ClientTLS dials the gRPC server, like that of the gateway.
+	ClientTLS TLS `yaml:"clientTLS"`
+}
+
+// TLS secures a connection. The same settings serve the server, which presents
+// the certificate and verifies clients against the CA, and its clients, which
+// verify the server against the CA and present their certificate for mTLS.
+type TLS struct {
+	Enabled  bool   `yaml:"enabled"`
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+	// CAFile holds the CA certificates of the peer, clients use the system roots without it.
+	CAFile string `yaml:"caFile"`
+	// ClientAuth makes servers require client certificates signed by the CA.
+	ClientAuth bool `yaml:"clientAuth"`
+	// ServerName is the name clients expect in the server certificate, the host by default.
+	ServerName string `yaml:"serverName"`
+	// Reload is how often the files are checked for new certificates, zero never reloads them.
+	Reload time.Duration `yaml:"reload" env-default:"1m"`
 }
 
 type Kafka struct {
@@ -41,11 +121,104 @@ type Kafka struct {
 	Partitions        int      `yaml:"partitions"`
 	ReplicationFactor int      `yaml:"replication"`
 	Group             string   `yaml:"group"`
+	TLS               TLS      `yaml:"tls"`
 }
 
 type Bot struct {
 	Host  string `yaml:"host"`
-	Token string `env:"TOKEN" env-required:"true"`
+	Token string `env:"TOKEN" env-required:"true" secret:"true"`
+}
+
+// Tracing configures the OpenTelemetry exporter. Empty Exporter disables tracing.
+type Tracing struct {
+	// Exporter is one of "stdout", "file" or "otlp".
+	Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER"`
+	File        string  `yaml:"file"`
+	Endpoint    string  `yaml:"endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	Insecure    bool    `yaml:"insecure"`
+	SampleRatio float64 `yaml:"sampleRatio" env-default:"1"`
+}
+
+// Metrics is the listener of the Prometheus /metrics endpoint. Empty Port disables it.
+type Metrics struct {
+	Host string `yaml:"host"`
+	Port string `yaml:"port"`
+}
+
+type Auth struct {
+	// Enabled makes the notes API reject requests without valid credentials.
+	Enabled   bool   `yaml:"enabled"`
+	JWTSecret string `yaml:"jwtSecret" env:"AUTH_JWT_SECRET" secret:"true"`
+	Issuer    string `yaml:"issuer"`
+	// APIKey and Token are the credentials this service presents to the notes API.
+	APIKey string `yaml:"apiKey" env:"NOTES_API_KEY" secret:"true"`
+	Token  string `yaml:"token" env:"NOTES_TOKEN" secret:"true"`
+}
+
+// Events configures the in-process bus behind the note event streams.
+type Events struct {
+	// History is the number of recent events kept for resuming subscriptions.
+	History int `yaml:"history" env-default:"1024"`
+	// Buffer is the number of events queued per subscriber before it is dropped as too slow.
+	Buffer int `yaml:"buffer" env-default:"64"`
+	// DuePoll is how often notes coming due are looked up, zero disables due events.
+	DuePoll time.Duration `yaml:"duePoll" env-default:"30s"`
+}
+
+// Idempotency configures how long the results of requests with idempotency keys are kept.
+type Idempotency struct {
+	TTL time.Duration `yaml:"ttl" env-default:"24h"`
+	// Purge is how often expired keys are deleted, zero leaves them to be overwritten.
+	Purge time.Duration `yaml:"purge" env-default:"1h"`
+}
+
+// RateLimit limits the requests of every caller, identified by its user or
+// service, or by its IP address when it is not authenticated.
+type RateLimit struct {
+	Enabled bool `yaml:"enabled"`
+	// Default applies to the routes that have no limit of their own.
+	Default Limit `yaml:"default"`
+	// Routes maps REST routes such as "POST /v1/notes" and gRPC methods such as
+	// "/gprc_notes.Notes/CreateNote" to their limits.
+	Routes map[string]Limit `yaml:"routes"`
+	// Idle is how long the bucket of a caller that made no requests is kept.
+	Idle time.Duration `yaml:"idle" env-default:"10m"`
+}
+
+// Limit is a token bucket refilled with Rate tokens per second up to Burst.
+// Zero Rate means no limit.
+type Limit struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+// Quotas limit the notes of every user, zero means no limit.
+type Quotas struct {
+	MaxNotes int `yaml:"maxNotes"`
+	// MaxReminders limits the notes whose reminder is not acknowledged.
+	MaxReminders int `yaml:"maxReminders"`
+}
+
+// redactedValue replaces the secrets of a redacted config.
+const redactedValue = "REDACTED"
+
+// Redacted returns a copy of c whose fields tagged secret:"true" are replaced
+// when they are set, so that it can be shown to operators.
+func (c Config) Redacted() Config {
+	redact(reflect.ValueOf(&c).Elem())
+	return c
+}
+
+func redact(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		switch {
+		case f.Kind() == reflect.Struct:
+			redact(f)
+		case v.Type().Field(i).Tag.Get("secret") == "true" && f.Kind() == reflect.String && f.String() != "":
+			f.SetString(redactedValue)
+		}
+	}
 }
 
 func New(configPath string) (Config, error) {
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedacted(t *testing.T) {
+	cfg := Config{
+		DB:   DB{Username: "postgres", Password: "qwerty"},
+		Auth: Auth{JWTSecret: "secret", Issuer: "notes"},
+	}
+
+	r := cfg.Redacted()
+	assert.Equal(t, "postgres", r.DB.Username)
+	assert.Equal(t, redactedValue, r.DB.Password)
+	assert.Equal(t, redactedValue, r.Auth.JWTSecret)
+	assert.Equal(t, "notes", r.Auth.Issuer)
+	assert.Empty(t, r.Auth.APIKey)
+	assert.Equal(t, "qwerty", cfg.DB.Password)
+}
@@ -0,0 +1,153 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+var ErrNotReady = errors.New("service is not ready")
+
+// Check reports a problem with a dependency by returning an error.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Report is the result of running a set of checks.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+func (r Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+// Checker runs liveness and readiness checks.
+type Checker struct {
+	mu        sync.RWMutex
+	liveness  []namedCheck
+	readiness []namedCheck
+	timeout   time.Duration
+}
+
+// New returns a Checker which gives every check at most timeout to finish.
+func New(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+	}
+}
+
+// AddLivenessCheck adds a check that must pass for the process to be considered alive.
+func (c *Checker) AddLivenessCheck(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.liveness = append(c.liveness, namedCheck{name: name, check: check})
+}
+
+// AddReadinessCheck adds a check that must pass before the service receives traffic.
+func (c *Checker) AddReadinessCheck(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readiness = append(c.readiness, namedCheck{name: name, check: check})
+}
+
+func (c *Checker) Live(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := c.liveness
+	c.mu.RUnlock()
+	return c.run(ctx, checks)
+}
+
+// Ready runs liveness and readiness checks.
+func (c *Checker) Ready(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := make([]namedCheck, 0, len(c.liveness)+len(c.readiness))
+	checks = append(checks, c.liveness...)
+	checks = append(checks, c.readiness...)
+	c.mu.RUnlock()
+	return c.run(ctx, checks)
+}
+
+// WaitReady runs readiness checks until they pass or ctx is done.
+func (c *Checker) WaitReady(ctx context.Context, interval time.Duration) error {
+	for {
+		r := c.Ready(ctx)
+		if r.OK() {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %v", ErrNotReady, r.Checks)
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (c *Checker) run(ctx context.Context, checks []namedCheck) Report {
+	r := Report{Status: StatusOK, Checks: make(map[string]string, len(checks))}
+
+	type result struct {
+		name string
+		err  error
+	}
+	results := make(chan result, len(checks))
+	for _, nc := range checks {
+		go func(nc namedCheck) {
+			ctx := ctx
+			if c.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, c.timeout)
+				defer cancel()
+			}
+			results <- result{name: nc.name, err: nc.check(ctx)}
+		}(nc)
+	}
+
+	for range checks {
+		res := <-results
+		if res.err != nil {
+			r.Status = StatusFail
+			r.Checks[res.name] = res.err.Error()
+			continue
+		}
+		r.Checks[res.name] = StatusOK
+	}
+	return r
+}
+
+// LiveHandler serves the liveness report, answering 503 when a check fails.
+func (c *Checker) LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, c.Live(r.Context()))
+	})
+}
+
+// ReadyHandler serves the readiness report, answering 503 when a check fails.
+func (c *Checker) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, c.Ready(r.Context()))
+	})
+}
+
+func writeReport(w http.ResponseWriter, r Report) {
+	w.Header().Set("Content-Type", "application/json")
+	if !r.OK() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	_ = json.NewEncoder(w).Encode(r)
+}
@@ -0,0 +1,60 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"notes/internal/pkg/health"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker(t *testing.T) {
+	h := health.New(time.Second)
+
+	ctx := context.Background()
+
+	t.Run("Test No Checks", func(t *testing.T) {
+		assert.True(t, h.Live(ctx).OK())
+		assert.True(t, h.Ready(ctx).OK())
+	})
+
+	t.Run("Test Failing Readiness", func(t *testing.T) {
+		h.AddLivenessCheck("live", func(context.Context) error { return nil })
+		h.AddReadinessCheck("db", func(context.Context) error { return errors.New("down") })
+
+		assert.True(t, h.Live(ctx).OK())
+
+		r := h.Ready(ctx)
+		assert.False(t, r.OK())
+		assert.Equal(t, "down", r.Checks["db"])
+		assert.Equal(t, health.StatusOK, r.Checks["live"])
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		h.ReadyHandler().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+		w = httptest.NewRecorder()
+		h.LiveHandler().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Test Check Timeout", func(t *testing.T) {
+		h := health.New(time.Millisecond * 10)
+		h.AddReadinessCheck("slow", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		r := h.Ready(ctx)
+		assert.False(t, r.OK())
+
+		ctxW, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+		defer cancel()
+		assert.ErrorIs(t, h.WaitReady(ctxW, time.Millisecond*10), health.ErrNotReady)
+	})
+}
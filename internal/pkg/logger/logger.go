@@ -25,6 +25,7 @@ var ErrNoLog = errors.New("logger can not be empty")
 
 type Logger struct {
 	*zap.SugaredLogger
+	level zap.AtomicLevel
 }
 
 func New(env string) (Logger, error) {
@@ -73,22 +74,33 @@ func New(env string) (Logger, error) {
 		ErrorOutputPaths: outputErr,
 	}
 
-	core, err := getCore(logLvl, config)
+	core, err := getCore(config)
 
 	logg := zap.New(core)
 	if err != nil {
 		return Logger{}, err
 	}
 
-	return Logger{logg.Sugar()}, nil
+	return Logger{SugaredLogger: logg.Sugar(), level: config.Level}, nil
 }
 
-func getCore(logLvl zapcore.Level, config zap.Config) (zapcore.Core, error) {
+// Level returns the minimum level of logged messages.
+func (l Logger) Level() zapcore.Level {
+	return l.level.Level()
+}
+
+// SetLevel changes the minimum level of the logger and all its copies at runtime.
+// Errors are logged at any level.
+func (l Logger) SetLevel(lvl zapcore.Level) {
+	l.level.SetLevel(lvl)
+}
+
+func getCore(config zap.Config) (zapcore.Core, error) {
 	highPriority := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
 		return lvl >= zapcore.ErrorLevel
 	})
 	levelEnabler := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-		return lvl < zap.ErrorLevel && lvl >= logLvl
+		return lvl < zap.ErrorLevel && config.Level.Enabled(lvl)
 	})
 
 	ws, err := toMultiSyncer(config.OutputPaths)
@@ -2,27 +2,48 @@ package kafkabroker
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"notes/internal/pkg/config"
 	"notes/internal/pkg/models"
+	"notes/internal/pkg/tlsconfig"
 	"strconv"
 	"time"
 
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("notes/internal/pkg/messaging/kafkabroker")
+
 type KafkaBroker struct {
 	cfg    config.Kafka
 	conn   *kafka.Conn
 	writer *kafka.Writer
 	reader *kafka.Reader
+	// tls is nil when TLS is disabled.
+	tls *tls.Config
 }
 
-func New(cfg config.Kafka) *KafkaBroker {
-	return &KafkaBroker{
+// New returns a broker for cfg, reading the certificates of the brokers
+// when TLS is enabled.
+func New(cfg config.Kafka) (*KafkaBroker, error) {
+	kb := &KafkaBroker{
 		cfg: cfg,
 	}
+	if cfg.TLS.Enabled {
+		c, err := tlsconfig.Client(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		kb.tls = c
+	}
+	return kb, nil
 }
 
 func (kb *KafkaBroker) RegisterKafkaWriter() error {
@@ -34,6 +55,9 @@ func (kb *KafkaBroker) RegisterKafkaWriter() error {
 		Topic:    kb.cfg.Topic,
 		Balancer: &kafka.LeastBytes{},
 	}
+	if kb.tls != nil {
+		kb.writer.Transport = &kafka.Transport{TLS: kb.tls}
+	}
 	return nil
 }
 
@@ -47,6 +71,7 @@ func (kb *KafkaBroker) RegisterKafkaReader() error {
 		MaxBytes:    10e6,
 		GroupID:     kb.cfg.Group,
 		StartOffset: kafka.FirstOffset,
+		Dialer:      kb.dialer(),
 	})
 	return nil
 }
@@ -58,12 +83,34 @@ func (kb *KafkaBroker) Send(ctx context.Context, m models.Message) error {
 			return err
 		}
 	}
+	ctx, span := tracer.Start(ctx, kb.cfg.Topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingDestinationName(kb.cfg.Topic),
+			semconv.MessagingOperationPublish,
+		),
+	)
+	defer span.End()
+
+	headers := make(map[string]string, len(m.Headers))
+	for k, v := range m.Headers {
+		headers[k] = v
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(headers))
+
 	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
 	defer cancel()
-	return kb.writer.WriteMessages(ctx, kafka.Message{
-		Key:   m.Key,
-		Value: m.Value,
+	err := kb.writer.WriteMessages(ctx, kafka.Message{
+		Key:     m.Key,
+		Value:   m.Value,
+		Headers: toKafkaHeaders(headers),
 	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
 }
 
 func (kb *KafkaBroker) Receive(ctx context.Context) (models.Message, error) {
@@ -80,10 +127,40 @@ func (kb *KafkaBroker) Receive(ctx context.Context) (models.Message, error) {
 	var mm models.Message
 	mm.Key = m.Key
 	mm.Value = m.Value
+	mm.Headers = fromKafkaHeaders(m.Headers)
 
 	return mm, nil
 }
 
+func toKafkaHeaders(h map[string]string) []kafka.Header {
+	headers := make([]kafka.Header, 0, len(h))
+	for k, v := range h {
+		headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	return headers
+}
+
+func fromKafkaHeaders(h []kafka.Header) map[string]string {
+	headers := make(map[string]string, len(h))
+	for _, v := range h {
+		headers[v.Key] = string(v.Value)
+	}
+	return headers
+}
+
+// Ping checks that a broker is reachable and answers requests. It does not
+// require the topic, which the publisher creates on its first connection.
+func (kb *KafkaBroker) Ping(ctx context.Context) error {
+	conn, err := kb.dialer().DialContext(ctx, "tcp", kb.cfg.Brokers[0])
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Brokers()
+	return err
+}
+
 func (kb *KafkaBroker) Shutdown() error {
 	var errW, errR error
 	if kb.writer != nil {
@@ -108,14 +185,24 @@ func (kb *KafkaBroker) Shutdown() error {
 	return fmt.Errorf(errMessage, errW, errR, errC)
 }
 
+// dialer connects to the brokers like kafka.DefaultDialer, over TLS when it is enabled.
+func (kb *KafkaBroker) dialer() *kafka.Dialer {
+	return &kafka.Dialer{
+		Timeout:   time.Second * 10,
+		DualStack: true,
+		TLS:       kb.tls,
+	}
+}
+
 func (kb *KafkaBroker) connect(cfg config.Kafka) error {
+	d := kb.dialer()
 	n := 1e9
 
-	conn, err := kafka.Dial("tcp", cfg.Brokers[0])
+	conn, err := d.Dial("tcp", cfg.Brokers[0])
 	for err != nil {
 		time.Sleep(time.Duration(n))
 		n += 3e9
-		conn, err = kafka.Dial("tcp", cfg.Brokers[0])
+		conn, err = d.Dial("tcp", cfg.Brokers[0])
 		if n < 20e9 {
 			continue
 		}
@@ -129,11 +216,11 @@ func (kb *KafkaBroker) connect(cfg config.Kafka) error {
 	}
 
 	var controllerConn *kafka.Conn
-	controllerConn, err = kafka.Dial("tcp", net.JoinHostPort(contr.Host, strconv.Itoa(contr.Port)))
+	controllerConn, err = d.Dial("tcp", net.JoinHostPort(contr.Host, strconv.Itoa(contr.Port)))
 	for err != nil {
 		time.Sleep(time.Duration(n))
 		n += 3e9
-		controllerConn, err = kafka.Dial("tcp", net.JoinHostPort(contr.Host, strconv.Itoa(contr.Port)))
+		controllerConn, err = d.Dial("tcp", net.JoinHostPort(contr.Host, strconv.Itoa(contr.Port)))
 		if n < 20e9 {
 			continue
 		}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"notes/internal/pkg/config"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Server is a standalone listener that exposes the default Prometheus registry on /metrics.
+type Server struct {
+	cfg config.Metrics
+	mux *http.ServeMux
+	srv *http.Server
+}
+
+func New(cfg config.Metrics) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
+		EnableOpenMetrics: true,
+	}))
+
+	return &Server{
+		cfg: cfg,
+		mux: mux,
+		srv: &http.Server{
+			Addr:              cfg.Host + cfg.Port,
+			Handler:           mux,
+			ReadHeaderTimeout: time.Second * 5,
+		},
+	}
+}
+
+// Handle registers an additional handler on the metrics listener.
+func (s *Server) Handle(pattern string, h http.Handler) {
+	s.mux.Handle(pattern, h)
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return nil
+	default:
+		if err := s.srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	}
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
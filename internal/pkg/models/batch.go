@@ -0,0 +1,31 @@
+package models
+
+// BatchMode tells how a batch handles failing items.
+type BatchMode string
+
+const (
+	// BatchAtomic applies all items or, when one fails, none of them.
+	BatchAtomic BatchMode = "atomic"
+	// BatchPartial applies the items that succeed and reports the failures per item.
+	BatchPartial BatchMode = "partial"
+)
+
+// NoteUpdate sets Fields of Note, see NonZeroFields for nil Fields.
+type NoteUpdate struct {
+	Note   Note        `json:"note"`
+	Fields []NoteField `json:"fields,omitempty"`
+}
+
+// NoteRef identifies a note at a version, zero matches any version.
+type NoteRef struct {
+	ID      uint64 `json:"id"`
+	Version uint64 `json:"version,omitempty"`
+}
+
+// BatchResult is the outcome of one item of a batch. ID and Version are those of
+// the created or updated note, Err is set when the item failed.
+type BatchResult struct {
+	ID      uint64
+	Version uint64
+	Err     error
+}
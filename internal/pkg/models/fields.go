@@ -0,0 +1,34 @@
+package models
+
+// NoteField names a field of a note that can be updated.
+type NoteField string
+
+const (
+	FieldTitle       NoteField = "title"
+	FieldDescription NoteField = "description"
+	FieldDateNotify  NoteField = "date_notify"
+	FieldDelay       NoteField = "delay"
+	FieldTags        NoteField = "tags"
+)
+
+// NonZeroFields returns the fields of n that are set. Tags are set when they are not nil.
+// It is the field set of updates that can't express clearing a field.
+func NonZeroFields(n Note) []NoteField {
+	fields := make([]NoteField, 0, 5)
+	if n.Title != "" {
+		fields = append(fields, FieldTitle)
+	}
+	if n.Description != "" {
+		fields = append(fields, FieldDescription)
+	}
+	if !n.DateNotify.IsZero() {
+		fields = append(fields, FieldDateNotify)
+	}
+	if n.Delay != 0 {
+		fields = append(fields, FieldDelay)
+	}
+	if n.Tags != nil {
+		fields = append(fields, FieldTags)
+	}
+	return fields
+}
@@ -1,10 +1,17 @@
 package models
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// HeaderOwnerID is the message header with the ID of the user to notify.
+const HeaderOwnerID = "owner-id"
 
 type Message struct {
-	Key   []byte `json:"key"`
-	Value []byte `json:"value"`
+	Key     []byte            `json:"key"`
+	Value   []byte            `json:"value"`
+	Headers map[string]string `json:"headers,omitempty"`
 }
 
 func NoteToMessage(n Note) (Message, error) {
@@ -16,8 +23,12 @@ func NoteToMessage(n Note) (Message, error) {
 	if err != nil {
 		return Message{}, err
 	}
-	return Message{
+	m := Message{
 		Key:   k,
 		Value: v,
-	}, nil
+	}
+	if n.OwnerID != 0 {
+		m.Headers = map[string]string{HeaderOwnerID: strconv.FormatUint(n.OwnerID, 10)}
+	}
+	return m, nil
 }
@@ -20,6 +20,7 @@ func TestMessage(t *testing.T) {
 		DateAdded:   tm,
 		DateNotify:  tm,
 		Delay:       time.Minute * 20,
+		OwnerID:     3,
 	}
 
 	m, err := models.NoteToMessage(n)
@@ -34,4 +35,5 @@ func TestMessage(t *testing.T) {
 	err = json.Unmarshal(m.Value, &testNote)
 	assert.NoError(t, err)
 	assert.Equal(t, n, testNote)
+	assert.Equal(t, "3", m.Headers[models.HeaderOwnerID])
 }
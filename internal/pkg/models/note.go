@@ -9,4 +9,99 @@ type Note struct {
 	DateAdded   time.Time     `json:"dateAdded"`
 	DateNotify  time.Time     `json:"dateNotify"`
 	Delay       time.Duration `json:"delay"`
+	OwnerID     uint64        `json:"ownerId,omitempty"`
+	Tags        []string      `json:"tags"`
+	// Version is incremented by every change of the note.
+	Version uint64 `json:"version,omitempty"`
+	// AcknowledgedAt is set while the reminder is acknowledged, such notes are not due.
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty"`
+}
+
+// NotesFilter selects the notes returned by GetNotes.
+type NotesFilter struct {
+	// Interval limits notes to those due within it. Zero means all notes.
+	Interval time.Duration
+	// Tags limits notes to those with any of the tags, or all of them when MatchAllTags is set.
+	Tags         []string
+	MatchAllTags bool
+	// SortBy is one of the SortBy* columns, notes are sorted by id when it is empty.
+	SortBy     string
+	Descending bool
+	// PageSize limits the number of notes in a page, zero means no limit.
+	PageSize int
+	// PageToken continues the listing after the page that returned it.
+	PageToken string
+}
+
+// Columns notes can be sorted by.
+const (
+	SortByID         = "id"
+	SortByDateNotify = "date_notify"
+	SortByDateAdded  = "date_added"
+)
+
+type NotesPage struct {
+	Notes []Note `json:"notes"`
+	// NextPageToken is empty on the last page.
+	NextPageToken string `json:"nextPageToken,omitempty"`
+}
+
+type TagCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// NoteCounts are the numbers of notes of an owner checked against its quotas.
+type NoteCounts struct {
+	Notes int
+	// Reminders counts the notes whose reminder is not acknowledged.
+	Reminders int
+}
+
+type User struct {
+	ID        uint64    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SearchQuery is a full-text query over note titles and descriptions.
+type SearchQuery struct {
+	Query  string
+	Limit  int
+	Offset int
+}
+
+type SearchResult struct {
+	Note Note    `json:"note"`
+	Rank float64 `json:"rank"`
+	// Snippet is HTML: the escaped text of the note with the matches in <b> tags.
+	Snippet string `json:"snippet"`
+}
+
+type SearchPage struct {
+	Results []SearchResult `json:"results"`
+	// NextOffset is the offset of the next page, zero when there are no more results.
+	NextOffset int `json:"nextOffset,omitempty"`
+}
+
+type NoteEventType string
+
+const (
+	NoteCreated NoteEventType = "created"
+	NoteUpdated NoteEventType = "updated"
+	NoteDeleted NoteEventType = "deleted"
+	// NoteDue is published when the notify date of a note is reached.
+	NoteDue NoteEventType = "due"
+)
+
+// NoteEvent describes a change of a note.
+type NoteEvent struct {
+	// ID is assigned by the event bus and can be used to resume a subscription after it.
+	ID      string        `json:"id"`
+	Type    NoteEventType `json:"type"`
+	NoteID  uint64        `json:"noteId"`
+	OwnerID uint64        `json:"ownerId,omitempty"`
+	// Note is the state after the change, nil for deleted notes.
+	Note *Note     `json:"note,omitempty"`
+	Time time.Time `json:"time"`
 }
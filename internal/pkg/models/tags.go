@@ -0,0 +1,32 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// MaxTagLength is the longest tag name that is stored.
+const MaxTagLength = 64
+
+// NormalizeTags lowercases and trims tags, drops empty ones and duplicates and sorts the rest.
+// The result is never nil, so an empty input clears the tags of a note.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]struct{}, len(tags))
+	res := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if r := []rune(t); len(r) > MaxTagLength {
+			t = string(r[:MaxTagLength])
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		res = append(res, t)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,34 @@
+package tlsconfig
+
+import (
+	"errors"
+	"notes/internal/pkg/config"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var ErrPlaintextProxy = errors.New("the gRPC server uses TLS, enable the client TLS of its proxies")
+
+// GRPCCredentials returns the transport credentials of a gRPC client of a
+// server configured with cfg, plaintext when TLS is disabled.
+func GRPCCredentials(cfg config.TLS) (credentials.TransportCredentials, error) {
+	if !cfg.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+	c, err := Client(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(c), nil
+}
+
+// ProxyCredentials returns the transport credentials of a proxy, such as the
+// gateway, dialing a gRPC server configured with server. The proxy presents its
+// own client certificate, never the server one.
+func ProxyCredentials(client, server config.TLS) (credentials.TransportCredentials, error) {
+	if server.Enabled && !client.Enabled {
+		return nil, ErrPlaintextProxy
+	}
+	return GRPCCredentials(client)
+}
@@ -0,0 +1,190 @@
+// Package tlsconfig builds the TLS configs of servers and clients from
+// config.TLS. Certificates are reloaded when their files change, so that they
+// can be renewed without a restart.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"notes/internal/pkg/config"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNoKeyPair = errors.New("certificate and key files must be set together")
+	ErrNoCert    = errors.New("servers need a certificate")
+	ErrBadCA     = errors.New("no certificates found in CA file")
+)
+
+// nextProtos are offered to clients, gRPC needs HTTP/2.
+var nextProtos = []string{"h2", "http/1.1"}
+
+// Reloader holds the certificates of a config.TLS and reloads them when their
+// files have changed, checking at most every Reload.
+type Reloader struct {
+	cfg config.TLS
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+	modTime time.Time
+	checked time.Time
+}
+
+// New returns a Reloader of cfg, its files are read by Load.
+func New(cfg config.TLS) *Reloader {
+	return &Reloader{cfg: cfg}
+}
+
+// Load reads the certificate, key and CA files.
+func (r *Reloader) Load() error {
+	if (r.cfg.CertFile == "") != (r.cfg.KeyFile == "") {
+		return ErrNoKeyPair
+	}
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return err
+	}
+
+	var cert *tls.Certificate
+	if r.cfg.CertFile != "" {
+		c, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+		if err != nil {
+			return err
+		}
+		cert = &c
+	}
+
+	var pool *x509.CertPool
+	if r.cfg.CAFile != "" {
+		pem, err := os.ReadFile(r.cfg.CAFile)
+		if err != nil {
+			return err
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("%w: %s", ErrBadCA, r.cfg.CAFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert, r.pool, r.modTime, r.checked = cert, pool, modTime, time.Now()
+	return nil
+}
+
+// ServerConfig returns the config of a server presenting the certificate, and
+// requiring client certificates signed by the CA when ClientAuth is set.
+func (r *Reloader) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.reloadIfChanged()
+
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			if r.cert == nil {
+				return nil, ErrNoCert
+			}
+			c := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   nextProtos,
+				Certificates: []tls.Certificate{*r.cert},
+			}
+			if r.cfg.ClientAuth {
+				c.ClientAuth = tls.RequireAndVerifyClientCert
+				c.ClientCAs = r.pool
+			}
+			return c, nil
+		},
+	}
+}
+
+// ClientConfig returns the config of a client verifying the server against
+// the CA, or the system roots without one, and presenting the certificate if
+// any. The CA is read when the config is made, only the certificate is reloaded.
+func (r *Reloader) ClientConfig() *tls.Config {
+	r.mu.RLock()
+	pool := r.pool
+	r.mu.RUnlock()
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    pool,
+		ServerName: r.cfg.ServerName,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			r.reloadIfChanged()
+
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			if r.cert == nil {
+				// No certificate is sent, the server decides whether it needs one.
+				return &tls.Certificate{}, nil
+			}
+			return r.cert, nil
+		},
+	}
+}
+
+// Server loads cfg and returns its server config.
+func Server(cfg config.TLS) (*tls.Config, error) {
+	r := New(cfg)
+	if err := r.Load(); err != nil {
+		return nil, err
+	}
+	return r.ServerConfig(), nil
+}
+
+// Client loads cfg and returns its client config.
+func Client(cfg config.TLS) (*tls.Config, error) {
+	r := New(cfg)
+	if err := r.Load(); err != nil {
+		return nil, err
+	}
+	return r.ClientConfig(), nil
+}
+
+// reloadIfChanged reloads the files when Reload has passed since the last
+// check and one of them was modified. A failed reload keeps the loaded
+// certificates, it is retried at the next check.
+func (r *Reloader) reloadIfChanged() {
+	if r.cfg.Reload <= 0 {
+		return
+	}
+	r.mu.Lock()
+	if time.Since(r.checked) < r.cfg.Reload {
+		r.mu.Unlock()
+		return
+	}
+	r.checked = time.Now()
+	loaded := r.modTime
+	r.mu.Unlock()
+
+	modTime, err := r.latestModTime()
+	if err != nil || !modTime.After(loaded) {
+		return
+	}
+	_ = r.Load()
+}
+
+func (r *Reloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, f := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.CAFile} {
+		if f == "" {
+			continue
+		}
+		fi, err := os.Stat(f)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if fi.ModTime().After(latest) {
+			latest = fi.ModTime()
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,132 @@
+package tlsconfig_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"notes/internal/pkg/config"
+	"notes/internal/pkg/tlsconfig"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type certs struct {
+	caFile, serverCert, serverKey, clientCert, clientKey string
+}
+
+// writeCerts writes a CA and a server and a client certificate signed by it to dir.
+func writeCerts(t *testing.T, dir string) certs {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "notes test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, ca, ca, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	c := certs{caFile: filepath.Join(dir, "ca.pem")}
+	writePEM(t, c.caFile, "CERTIFICATE", caDER)
+
+	leaf := func(name string, serial int64, usage x509.ExtKeyUsage) (string, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+
+		certFile, keyFile := filepath.Join(dir, name+".pem"), filepath.Join(dir, name+"-key.pem")
+		writePEM(t, certFile, "CERTIFICATE", der)
+		writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+		return certFile, keyFile
+	}
+	c.serverCert, c.serverKey = leaf("server", 2, x509.ExtKeyUsageServerAuth)
+	c.clientCert, c.clientKey = leaf("client", 3, x509.ExtKeyUsageClientAuth)
+	return c
+}
+
+func writePEM(t *testing.T, path, typ string, der []byte) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600))
+}
+
+func TestMutualTLS(t *testing.T) {
+	c := writeCerts(t, t.TempDir())
+
+	serverCfg, err := tlsconfig.Server(config.TLS{
+		Enabled:    true,
+		CertFile:   c.serverCert,
+		KeyFile:    c.serverKey,
+		CAFile:     c.caFile,
+		ClientAuth: true,
+	})
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	srv.TLS = serverCfg
+	srv.StartTLS()
+	defer srv.Close()
+
+	get := func(cfg config.TLS) (*http.Response, error) {
+		clientCfg, err := tlsconfig.Client(cfg)
+		require.NoError(t, err)
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientCfg}}
+		return client.Get(srv.URL)
+	}
+
+	res, err := get(config.TLS{Enabled: true, CertFile: c.clientCert, KeyFile: c.clientKey, CAFile: c.caFile})
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "client", string(body))
+
+	// Without a client certificate the handshake fails.
+	_, err = get(config.TLS{Enabled: true, CAFile: c.caFile})
+	assert.Error(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	c := writeCerts(t, t.TempDir())
+
+	_, err := tlsconfig.Server(config.TLS{Enabled: true, CertFile: c.serverCert})
+	assert.ErrorIs(t, err, tlsconfig.ErrNoKeyPair)
+
+	_, err = tlsconfig.Client(config.TLS{Enabled: true, CAFile: c.serverKey})
+	assert.ErrorIs(t, err, tlsconfig.ErrBadCA)
+
+	_, err = tlsconfig.ProxyCredentials(config.TLS{}, config.TLS{Enabled: true})
+	assert.ErrorIs(t, err, tlsconfig.ErrPlaintextProxy)
+}
@@ -0,0 +1,152 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const grpcTracerName = "notes/internal/pkg/tracing/grpc"
+
+// MetadataCarrier adapts gRPC metadata to propagation.TextMapCarrier.
+type MetadataCarrier metadata.MD
+
+func (mc MetadataCarrier) Get(key string) string {
+	v := metadata.MD(mc).Get(key)
+	if len(v) == 0 {
+		return ""
+	}
+	return v[0]
+}
+
+func (mc MetadataCarrier) Set(key, value string) {
+	metadata.MD(mc).Set(key, value)
+}
+
+func (mc MetadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(mc))
+	for k := range mc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// ExtractIncoming returns ctx carrying the remote span context found in the incoming metadata.
+func ExtractIncoming(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, MetadataCarrier(md))
+}
+
+// InjectOutgoing adds the span context of ctx to the outgoing metadata.
+func InjectOutgoing(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, MetadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// UnaryClientInterceptor starts a client span for every call and passes its context to the server.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		ctx, span := startClientSpan(ctx, method, cc)
+		defer span.End()
+
+		err := invoker(InjectOutgoing(ctx), method, req, reply, cc, opts...)
+		RecordGRPCStatus(span, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor starts a client span for every stream and passes its
+// context to the server. The span ends with the stream: when a receive fails,
+// io.EOF included, or when ctx is done.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		ctx, span := startClientSpan(ctx, method, cc)
+		cs, err := streamer(InjectOutgoing(ctx), desc, cc, method, opts...)
+		if err != nil {
+			RecordGRPCStatus(span, err)
+			span.End()
+			return nil, err
+		}
+
+		s := &tracedStream{ClientStream: cs, span: span, done: make(chan struct{})}
+		go func() {
+			select {
+			case <-ctx.Done():
+				s.end(status.FromContextError(ctx.Err()).Err())
+			case <-s.done:
+			}
+		}()
+		return s, nil
+	}
+}
+
+func startClientSpan(ctx context.Context, method string, cc *grpc.ClientConn) (context.Context, trace.Span) {
+	return otel.Tracer(grpcTracerName).Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			attribute.String("rpc.method", method),
+			attribute.String("net.peer.name", cc.Target()),
+		),
+	)
+}
+
+// tracedStream ends its span once the stream is over.
+type tracedStream struct {
+	grpc.ClientStream
+	span trace.Span
+	once sync.Once
+	done chan struct{}
+}
+
+func (s *tracedStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.end(err)
+	}
+	return err
+}
+
+func (s *tracedStream) end(err error) {
+	s.once.Do(func() {
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+		RecordGRPCStatus(s.span, err)
+		s.span.End()
+		close(s.done)
+	})
+}
+
+// RecordGRPCStatus sets the gRPC status code of err on span.
+func RecordGRPCStatus(span trace.Span, err error) {
+	st, _ := status.FromError(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, st.Message())
+	}
+}
@@ -6,8 +6,11 @@ import (
 )
 
 func New(cfg config.Kafka) (*kafkabroker.KafkaBroker, error) {
-	kb := kafkabroker.New(cfg)
-	err := kb.RegisterKafkaWriter()
+	kb, err := kafkabroker.New(cfg)
+	if err != nil {
+		return &kafkabroker.KafkaBroker{}, err
+	}
+	err = kb.RegisterKafkaWriter()
 	if err != nil {
 		return &kafkabroker.KafkaBroker{}, err
 	}
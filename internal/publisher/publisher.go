@@ -5,6 +5,44 @@ import (
 	"fmt"
 	"notes/internal/pkg/logger"
 	"notes/internal/pkg/models"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var tracer = otel.Tracer("notes/internal/publisher")
+
+var (
+	notesFetched = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "notes_fetched_total",
+		Help:      "Number of due notes fetched from the notes API.",
+	})
+	notesSent = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "notes_sent_total",
+		Help:      "Number of notes sent to the queue.",
+	})
+	notesFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "notes_failed_total",
+		Help:      "Number of notes that could not be published by stage.",
+	}, []string{"stage"})
+	fetchErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "fetch_errors_total",
+		Help:      "Number of failed fetches from the notes API.",
+	})
+	sendDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "publisher",
+		Name:      "send_duration_seconds",
+		Help:      "Latency of sending a single message to the queue.",
+		Buckets:   prometheus.DefBuckets,
+	})
 )
 
 type SenderShutdowner interface {
@@ -31,33 +69,45 @@ func New(s SenderShutdowner, f Fetcher, logg logger.Logger) (Publisher, error) {
 }
 
 func (ks *Publisher) Publish(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "Publisher.Publish")
+	defer span.End()
+
 	notes, err := ks.f.Fetch(ctx)
 	if err != nil {
+		fetchErrors.Inc()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return err
 	}
+	span.SetAttributes(attribute.Int("notes.fetched", len(notes)))
+	notesFetched.Add(float64(len(notes)))
 
 	for _, n := range notes {
 		m, err := models.NoteToMessage(n)
 		if err != nil {
 			ks.l.Error(fmt.Errorf("can not convert note to message error: %w", err).Error())
+			notesFailed.WithLabelValues("convert").Inc()
 			continue
 		}
 
+		start := time.Now()
 		err = ks.s.Send(ctx, m)
+		sendDuration.Observe(time.Since(start).Seconds())
 		if err != nil {
 			ks.l.Error(fmt.Errorf("can not send message to queque error: %w", err).Error())
+			notesFailed.WithLabelValues("send").Inc()
 			continue
 		}
+		notesSent.Inc()
 	}
 	return nil
 }
 
 func (ks *Publisher) Shutdown(ctx context.Context) error {
-	ok := make(chan error)
-	defer close(ok)
+	// Buffered, so that the goroutine can finish after a timeout.
+	ok := make(chan error, 1)
 	go func() {
 		ok <- ks.s.Shutdown()
-		close(ok)
 	}()
 
 	select {
@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS api_keys (
+    id SERIAL PRIMARY KEY,
+    name varchar(64) NOT NULL,
+    key_hash char(64) NOT NULL UNIQUE,
+    kind varchar(16) NOT NULL DEFAULT 'user',
+    principal_id bigint NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    revoked_at timestamptz
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS users (
+    id SERIAL PRIMARY KEY,
+    name varchar(64) NOT NULL UNIQUE,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS owner_id integer REFERENCES users(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS notes_owner_id_idx ON notes(owner_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS notes_owner_id_idx;
+ALTER TABLE notes DROP COLUMN IF EXISTS owner_id;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS tags (
+    id SERIAL PRIMARY KEY,
+    name varchar(64) NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS note_tags (
+    note_id integer NOT NULL REFERENCES notes(id) ON DELETE CASCADE,
+    tag_id integer NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (note_id, tag_id)
+);
+CREATE INDEX IF NOT EXISTS note_tags_tag_id_idx ON note_tags(tag_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS note_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,10 @@
+-- +goose Up
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS search tsvector;
+UPDATE notes SET search =
+    setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(description, '')), 'B');
+CREATE INDEX IF NOT EXISTS notes_search_idx ON notes USING GIN (search);
+
+-- +goose Down
+DROP INDEX IF EXISTS notes_search_idx;
+ALTER TABLE notes DROP COLUMN IF EXISTS search;
@@ -0,0 +1,7 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS notes_date_notify_id_idx ON notes (date_notify, id);
+CREATE INDEX IF NOT EXISTS notes_date_added_id_idx ON notes (date_added, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS notes_date_added_id_idx;
+DROP INDEX IF EXISTS notes_date_notify_id_idx;
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_note_change() RETURNS trigger AS $$
+DECLARE
+    rec notes%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        rec := OLD;
+    ELSE
+        rec := NEW;
+    END IF;
+    PERFORM pg_notify('note_events', json_build_object(
+        'op', lower(TG_OP),
+        'id', rec.id,
+        'owner_id', COALESCE(rec.owner_id, 0)
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- Search vector updates are not changes of the note itself.
+CREATE TRIGGER notes_notify_insert_delete AFTER INSERT OR DELETE ON notes
+    FOR EACH ROW EXECUTE FUNCTION notify_note_change();
+CREATE TRIGGER notes_notify_update AFTER UPDATE OF title, description, date_notify, delay, owner_id ON notes
+    FOR EACH ROW EXECUTE FUNCTION notify_note_change();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS notes_notify_update ON notes;
+DROP TRIGGER IF EXISTS notes_notify_insert_delete ON notes;
+DROP FUNCTION IF EXISTS notify_note_change();
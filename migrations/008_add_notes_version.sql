@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+
+-- Every change bumps the version, including tag-only ones.
+DROP TRIGGER IF EXISTS notes_notify_update ON notes;
+CREATE TRIGGER notes_notify_update AFTER UPDATE OF title, description, date_notify, delay, owner_id, version ON notes
+    FOR EACH ROW EXECUTE FUNCTION notify_note_change();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS notes_notify_update ON notes;
+CREATE TRIGGER notes_notify_update AFTER UPDATE OF title, description, date_notify, delay, owner_id ON notes
+    FOR EACH ROW EXECUTE FUNCTION notify_note_change();
+ALTER TABLE notes DROP COLUMN IF EXISTS version;
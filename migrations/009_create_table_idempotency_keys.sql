@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    scope varchar(128) NOT NULL,
+    key varchar(255) NOT NULL,
+    fingerprint bytea NOT NULL,
+    -- status is NULL until the first request has completed.
+    status int,
+    header jsonb,
+    body bytea,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    expires_at timestamptz NOT NULL,
+    PRIMARY KEY (scope, key)
+);
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Acknowledged notes are not due until they are snoozed or rescheduled.
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS acknowledged_at timestamptz;
+
+-- +goose Down
+ALTER TABLE notes DROP COLUMN IF EXISTS acknowledged_at;
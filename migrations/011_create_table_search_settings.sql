@@ -0,0 +1,10 @@
+-- +goose Up
+-- language is the text search configuration notes.search was built with, the
+-- service rebuilds the vectors when db.searchLanguage is another one.
+CREATE TABLE IF NOT EXISTS search_settings (
+    language text NOT NULL
+);
+INSERT INTO search_settings(language) VALUES ('english');
+
+-- +goose Down
+DROP TABLE IF EXISTS search_settings;